@@ -0,0 +1,217 @@
+package container
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+)
+
+// The binary encoding of a container starts with a header made of the format version,
+// the kind of the container and the number of elements as a uvarint,
+// followed by the elements encoded with encoding/gob.
+// The elements of a stack are encoded from the bottom to the top.
+
+const binaryVersion = 1
+
+type binaryKind byte
+
+const (
+	kindSliceList binaryKind = iota + 1
+	kindLinkedList
+	kindSliceQueue
+	kindLinkedQueue
+	kindSliceStack
+	kindLinkedStack
+)
+
+var (
+	ErrBinaryData    = errors.New("error: encoded data cannot be truncated or inconsistent")
+	ErrBinaryKind    = errors.New("error: encoded data cannot be decoded into another kind of container")
+	ErrBinaryVersion = errors.New("error: encoded data cannot have an unsupported version")
+)
+
+func marshalBinary[E any](kind binaryKind, es []E) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte(binaryVersion)
+	buf.WriteByte(byte(kind))
+	var count [binary.MaxVarintLen64]byte
+	buf.Write(count[:binary.PutUvarint(count[:], uint64(len(es)))])
+	if len(es) > 0 {
+		if err := gob.NewEncoder(&buf).Encode(es); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func unmarshalBinary[E any](kind binaryKind, data []byte) ([]E, error) {
+	if len(data) < 2 {
+		return nil, ErrBinaryData
+	}
+	if data[0] != binaryVersion {
+		return nil, ErrBinaryVersion
+	}
+	if binaryKind(data[1]) != kind {
+		return nil, ErrBinaryKind
+	}
+	count, n := binary.Uvarint(data[2:])
+	if n <= 0 {
+		return nil, ErrBinaryData
+	}
+	data = data[2+n:]
+	if count == 0 {
+		if len(data) != 0 {
+			return nil, ErrBinaryData
+		}
+		return nil, nil
+	}
+	var es []E
+	r := bytes.NewReader(data)
+	if err := gob.NewDecoder(r).Decode(&es); err != nil {
+		return nil, err
+	}
+	// the decoder reads no further than the payload since r is an io.ByteReader
+	if uint64(len(es)) != count || r.Len() != 0 {
+		return nil, ErrBinaryData
+	}
+	return es, nil
+}
+
+func (sl *SliceList[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(kindSliceList, sl.ToSlice())
+}
+
+func (sl *SliceList[E]) UnmarshalBinary(data []byte) error {
+	es, err := unmarshalBinary[E](kindSliceList, data)
+	if err != nil {
+		return err
+	}
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.reset(es)
+	return nil
+}
+
+func (sl *SliceList[E]) GobEncode() ([]byte, error) {
+	return sl.MarshalBinary()
+}
+
+func (sl *SliceList[E]) GobDecode(data []byte) error {
+	return sl.UnmarshalBinary(data)
+}
+
+func (ll *LinkedList[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(kindLinkedList, ll.ToSlice())
+}
+
+func (ll *LinkedList[E]) UnmarshalBinary(data []byte) error {
+	es, err := unmarshalBinary[E](kindLinkedList, data)
+	if err != nil {
+		return err
+	}
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	ll.reset(es)
+	return nil
+}
+
+func (ll *LinkedList[E]) GobEncode() ([]byte, error) {
+	return ll.MarshalBinary()
+}
+
+func (ll *LinkedList[E]) GobDecode(data []byte) error {
+	return ll.UnmarshalBinary(data)
+}
+
+func (sq *SliceQueue[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(kindSliceQueue, sq.ToSlice())
+}
+
+func (sq *SliceQueue[E]) UnmarshalBinary(data []byte) error {
+	es, err := unmarshalBinary[E](kindSliceQueue, data)
+	if err != nil {
+		return err
+	}
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	sq.reset(es)
+	return nil
+}
+
+func (sq *SliceQueue[E]) GobEncode() ([]byte, error) {
+	return sq.MarshalBinary()
+}
+
+func (sq *SliceQueue[E]) GobDecode(data []byte) error {
+	return sq.UnmarshalBinary(data)
+}
+
+func (lq *LinkedQueue[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(kindLinkedQueue, lq.ToSlice())
+}
+
+func (lq *LinkedQueue[E]) UnmarshalBinary(data []byte) error {
+	es, err := unmarshalBinary[E](kindLinkedQueue, data)
+	if err != nil {
+		return err
+	}
+	lq.rw.Lock()
+	defer lq.rw.Unlock()
+	lq.reset(es)
+	return nil
+}
+
+func (lq *LinkedQueue[E]) GobEncode() ([]byte, error) {
+	return lq.MarshalBinary()
+}
+
+func (lq *LinkedQueue[E]) GobDecode(data []byte) error {
+	return lq.UnmarshalBinary(data)
+}
+
+func (ss *SliceStack[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(kindSliceStack, ss.ToSlice())
+}
+
+func (ss *SliceStack[E]) UnmarshalBinary(data []byte) error {
+	es, err := unmarshalBinary[E](kindSliceStack, data)
+	if err != nil {
+		return err
+	}
+	ss.rw.Lock()
+	defer ss.rw.Unlock()
+	ss.reset(es)
+	return nil
+}
+
+func (ss *SliceStack[E]) GobEncode() ([]byte, error) {
+	return ss.MarshalBinary()
+}
+
+func (ss *SliceStack[E]) GobDecode(data []byte) error {
+	return ss.UnmarshalBinary(data)
+}
+
+func (ls *LinkedStack[E]) MarshalBinary() ([]byte, error) {
+	return marshalBinary(kindLinkedStack, ls.ToSlice())
+}
+
+func (ls *LinkedStack[E]) UnmarshalBinary(data []byte) error {
+	es, err := unmarshalBinary[E](kindLinkedStack, data)
+	if err != nil {
+		return err
+	}
+	ls.rw.Lock()
+	defer ls.rw.Unlock()
+	ls.reset(es)
+	return nil
+}
+
+func (ls *LinkedStack[E]) GobEncode() ([]byte, error) {
+	return ls.MarshalBinary()
+}
+
+func (ls *LinkedStack[E]) GobDecode(data []byte) error {
+	return ls.UnmarshalBinary(data)
+}
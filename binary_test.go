@@ -0,0 +1,121 @@
+package container
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type binaryContainer interface {
+	Container[int]
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		src, dst binaryContainer
+	}{
+		{"SliceList", NewSliceList(1, 2, 3), NewSliceList(9)},
+		{"LinkedList", NewLinkedList(1, 2, 3), NewLinkedList(9)},
+		{"SliceQueue", NewSliceQueue(1, 2, 3), NewSliceQueue(9)},
+		{"LinkedQueue", NewLinkedQueue(1, 2, 3), NewLinkedQueue(9)},
+		{"SliceStack", NewSliceStack(1, 2, 3), NewSliceStack(9)},
+		{"LinkedStack", NewLinkedStack(1, 2, 3), NewLinkedStack(9)},
+		{"empty", NewSliceList[int](), NewSliceList(9)},
+		{"zero value", NewLinkedList(1, 2), new(LinkedList[int])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := tt.src.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := tt.dst.UnmarshalBinary(data); err != nil {
+				t.Fatal(err)
+			}
+			got, want := tt.dst.ToSlice(), tt.src.ToSlice()
+			if len(got) != 0 || len(want) != 0 {
+				if !reflect.DeepEqual(got, want) {
+					t.Fatalf("decoded %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBinaryGobField(t *testing.T) {
+	type record struct {
+		Name  string
+		Stack *LinkedStack[string]
+	}
+	src := record{"tags", NewLinkedStack("a", "b")}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+		t.Fatal(err)
+	}
+	var dst record
+	if err := gob.NewDecoder(&buf).Decode(&dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Name != "tags" {
+		t.Fatalf("Name = %q, want tags", dst.Name)
+	}
+	if got, err := dst.Stack.Pop(); err != nil || got != "b" {
+		t.Fatalf("Pop = %v, %v, want b on top", got, err)
+	}
+}
+
+func TestBinaryErrors(t *testing.T) {
+	valid, err := NewSliceList(1, 2, 3).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	empty, err := NewSliceList[int]().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	modify := func(data []byte, f func([]byte) []byte) []byte {
+		return f(append([]byte(nil), data...))
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"no header", []byte{binaryVersion}, ErrBinaryData},
+		{"unknown version", modify(valid, func(d []byte) []byte {
+			d[0] = binaryVersion + 1
+			return d
+		}), ErrBinaryVersion},
+		{"wrong kind", modify(valid, func(d []byte) []byte {
+			d[1] = byte(kindLinkedList)
+			return d
+		}), ErrBinaryKind},
+		{"missing count", valid[:2], ErrBinaryData},
+		{"wrong count", modify(valid, func(d []byte) []byte {
+			d[2] = 4
+			return d
+		}), ErrBinaryData},
+		{"trailing bytes", append(append([]byte(nil), valid...), 0), ErrBinaryData},
+		{"trailing bytes after no element", append(append([]byte(nil), empty...), 0), ErrBinaryData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sl := NewSliceList(7)
+			if err := sl.UnmarshalBinary(tt.data); !errors.Is(err, tt.want) {
+				t.Fatalf("UnmarshalBinary = %v, want %v", err, tt.want)
+			}
+			if got := sl.ToSlice(); !reflect.DeepEqual(got, []int{7}) {
+				t.Fatalf("the failed UnmarshalBinary changed the list to %v", got)
+			}
+		})
+	}
+	if err := NewSliceList(7).UnmarshalBinary(valid[:len(valid)-1]); err == nil {
+		t.Fatal("UnmarshalBinary of a truncated payload succeeded")
+	}
+}
@@ -0,0 +1,251 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BlockingQueue is a queue whose consumers can wait for elements and whose producers
+// can wait for space when the queue has a capacity.
+// A closed queue rejects new elements, but the remaining elements can still be taken.
+type BlockingQueue[E any] interface {
+	Queue[E]
+	Put(e E) error
+	Take(ctx context.Context) (E, error)
+	Offer(e E, timeout time.Duration) error
+	Poll(timeout time.Duration) (E, error)
+	Close()
+	Drain() []E
+	Err() error
+}
+
+// notifier wakes up the goroutines waiting for a container to change.
+// Waiters hold a snapshot of the channel, every change closes it, so all the waiters
+// wake up and check again. The zero value is ready to use.
+type notifier struct {
+	ch chan struct{}
+}
+
+// signal wakes up all the waiters, the lock passed to wait must be held
+func (n *notifier) signal() {
+	if n.ch != nil {
+		close(n.ch)
+		n.ch = nil
+	}
+}
+
+// wait releases mu until signal is called or ctx is done, mu must be held
+func (n *notifier) wait(ctx context.Context, mu sync.Locker) error {
+	if n.ch == nil {
+		n.ch = make(chan struct{})
+	}
+	ch := n.ch
+	mu.Unlock()
+	defer mu.Lock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// blockingQueue implements BlockingQueue on top of any Queue
+type blockingQueue[E any] struct {
+	q        Queue[E]
+	capacity int
+	closed   bool
+	err      error
+	mu       sync.Mutex
+	notify   notifier
+}
+
+func (bq *blockingQueue[E]) init(q Queue[E], capacity int) {
+	bq.q = q
+	bq.capacity = capacity
+}
+
+func (bq *blockingQueue[E]) full() bool {
+	return bq.capacity > 0 && bq.q.Size() >= bq.capacity
+}
+
+// signal wakes up all the waiters, bq.mu must be held
+func (bq *blockingQueue[E]) signal() {
+	bq.notify.signal()
+}
+
+// wait releases bq.mu until the queue changes or ctx is done, bq.mu must be held
+func (bq *blockingQueue[E]) wait(ctx context.Context) error {
+	return bq.notify.wait(ctx, &bq.mu)
+}
+
+func (bq *blockingQueue[E]) put(ctx context.Context, e E) error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	for {
+		if bq.closed {
+			return ErrQueueClosed
+		}
+		if !bq.full() {
+			bq.q.En(e)
+			bq.signal()
+			return nil
+		}
+		if err := bq.wait(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+func (bq *blockingQueue[E]) take(ctx context.Context) (E, error) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	for {
+		if !bq.q.IsEmpty() {
+			e, err := bq.q.De()
+			bq.signal()
+			return e, err
+		}
+		if bq.closed {
+			var e E
+			return e, ErrQueueClosed
+		}
+		if err := bq.wait(ctx); err != nil {
+			var e E
+			return e, err
+		}
+	}
+}
+
+func (bq *blockingQueue[E]) Clear() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	bq.q.Clear()
+	bq.signal()
+}
+
+func (bq *blockingQueue[E]) Get(i int) (E, error) {
+	return bq.q.Get(i)
+}
+
+func (bq *blockingQueue[E]) IsEmpty() bool {
+	return bq.q.IsEmpty()
+}
+
+func (bq *blockingQueue[E]) Iterator() Iterator[E] {
+	return bq.q.Iterator()
+}
+
+func (bq *blockingQueue[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	return reverseSnapshotIterator[E](bq.q)
+}
+
+func (bq *blockingQueue[E]) Size() int {
+	return bq.q.Size()
+}
+
+func (bq *blockingQueue[E]) SnapshotIterator() Iterator[E] {
+	return snapshotIterator[E](bq.q)
+}
+
+func (bq *blockingQueue[E]) ToSlice() []E {
+	return bq.q.ToSlice()
+}
+
+// En adds e like Put, waiting for space if the queue is full.
+// If the queue has been closed e is discarded and Err reports it.
+func (bq *blockingQueue[E]) En(e E) {
+	if err := bq.put(context.Background(), e); err != nil {
+		bq.mu.Lock()
+		defer bq.mu.Unlock()
+		if bq.err == nil {
+			bq.err = err
+		}
+	}
+}
+
+// De removes the front element without blocking
+func (bq *blockingQueue[E]) De() (E, error) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	e, err := bq.q.De()
+	if err == nil {
+		bq.signal()
+	}
+	return e, err
+}
+
+func (bq *blockingQueue[E]) GetFront() (E, error) {
+	return bq.q.GetFront()
+}
+
+func (bq *blockingQueue[E]) GetRear() (E, error) {
+	return bq.q.GetRear()
+}
+
+// Err returns the error of the first element En has discarded
+func (bq *blockingQueue[E]) Err() error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.err
+}
+
+// Put adds e, waiting for space if the queue is full
+func (bq *blockingQueue[E]) Put(e E) error {
+	return bq.put(context.Background(), e)
+}
+
+// Take removes the front element, waiting until there is one or ctx is done
+func (bq *blockingQueue[E]) Take(ctx context.Context) (E, error) {
+	return bq.take(ctx)
+}
+
+// Offer adds e, waiting at most timeout for space if the queue is full
+func (bq *blockingQueue[E]) Offer(e E, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := bq.put(ctx, e)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrQueueFull
+	}
+	return err
+}
+
+// Poll removes the front element, waiting at most timeout until there is one
+func (bq *blockingQueue[E]) Poll(timeout time.Duration) (E, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	e, err := bq.take(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return e, ErrQueueEmpty
+	}
+	return e, err
+}
+
+// Close stops the queue from accepting elements and wakes up all the waiters
+func (bq *blockingQueue[E]) Close() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.closed {
+		return
+	}
+	bq.closed = true
+	bq.signal()
+}
+
+// Drain removes and returns all the elements without blocking
+func (bq *blockingQueue[E]) Drain() []E {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	es := bq.q.ToSlice()
+	bq.q.Clear()
+	bq.signal()
+	return es
+}
+
+func (bq *blockingQueue[E]) String() string {
+	return fmt.Sprint(bq.q)
+}
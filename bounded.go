@@ -0,0 +1,98 @@
+package container
+
+import (
+	"context"
+	"sync"
+)
+
+// OverflowPolicy decides what a bounded container does with a new element when it is full
+type OverflowPolicy int
+
+const (
+	// OverflowReject rejects the new element with ErrQueueFull or ErrStackFull
+	OverflowReject OverflowPolicy = iota
+	// OverflowBlock waits until there is space for the new element, the container is closed
+	// or the context passed to PutContext is done
+	OverflowBlock
+	// OverflowDropOldest removes the oldest element to make space for the new element
+	OverflowDropOldest
+	// OverflowDropNewest discards the new element
+	OverflowDropNewest
+)
+
+// BoundedContainer is a container which holds at most Capacity elements.
+// Put adds an element according to the overflow policy of the container.
+// A closed container rejects new elements, but the remaining elements can still be taken.
+type BoundedContainer[E any] interface {
+	Container[E]
+	Capacity() int
+	Remaining() int
+	Put(e E) error
+	PutContext(ctx context.Context, e E) error
+	Close()
+}
+
+type bounded struct {
+	capacity int
+	policy   OverflowPolicy
+	closed   bool
+	mu       sync.Mutex
+	notFull  notifier
+}
+
+func (b *bounded) init(capacity int, policy OverflowPolicy) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	b.capacity = capacity
+	b.policy = policy
+}
+
+// admit reports whether a new element can be added after applying the overflow policy,
+// b.mu must be held
+func (b *bounded) admit(ctx context.Context, size func() int, dropOldest func(), errFull, errClosed error) (bool, error) {
+	for {
+		if b.closed {
+			return false, errClosed
+		}
+		if size() < b.capacity {
+			return true, nil
+		}
+		switch b.policy {
+		case OverflowBlock:
+			if err := b.notFull.wait(ctx, &b.mu); err != nil {
+				return false, err
+			}
+		case OverflowDropOldest:
+			dropOldest()
+		case OverflowDropNewest:
+			return false, nil
+		default:
+			return false, errFull
+		}
+	}
+}
+
+// admitInit is admit for the initial elements, it never blocks
+func (b *bounded) admitInit(size func() int, dropOldest func()) bool {
+	if size() < b.capacity {
+		return true
+	}
+	if b.policy == OverflowDropOldest {
+		dropOldest()
+		return true
+	}
+	return false
+}
+
+func (b *bounded) Capacity() int {
+	return b.capacity
+}
+
+// Close stops the container from accepting elements and wakes up the blocked producers
+func (b *bounded) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	b.notFull.signal()
+}
@@ -0,0 +1,122 @@
+package container
+
+import (
+	"context"
+	"fmt"
+)
+
+// BoundedQueue is a queue which holds at most Capacity elements.
+// A capacity less than 1 is treated as 1. The initial elements beyond the capacity
+// are discarded, unless the policy is OverflowDropOldest.
+type BoundedQueue[E any] struct {
+	bounded
+	q Queue[E]
+}
+
+func NewBoundedSliceQueue[E any](capacity int, policy OverflowPolicy, es ...E) *BoundedQueue[E] {
+	return newBoundedQueue[E](NewSliceQueue[E](), capacity, policy, es)
+}
+
+func NewBoundedLinkedQueue[E any](capacity int, policy OverflowPolicy, es ...E) *BoundedQueue[E] {
+	return newBoundedQueue[E](NewLinkedQueue[E](), capacity, policy, es)
+}
+
+func newBoundedQueue[E any](q Queue[E], capacity int, policy OverflowPolicy, es []E) *BoundedQueue[E] {
+	bq := &BoundedQueue[E]{q: q}
+	bq.init(capacity, policy)
+	for _, e := range es {
+		if bq.admitInit(bq.q.Size, bq.dropOldest) {
+			bq.q.En(e)
+		}
+	}
+	return bq
+}
+
+func (bq *BoundedQueue[E]) dropOldest() {
+	_, _ = bq.q.De()
+}
+
+func (bq *BoundedQueue[E]) Clear() {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	bq.q.Clear()
+	bq.notFull.signal()
+}
+
+func (bq *BoundedQueue[E]) Get(i int) (E, error) {
+	return bq.q.Get(i)
+}
+
+func (bq *BoundedQueue[E]) IsEmpty() bool {
+	return bq.q.IsEmpty()
+}
+
+func (bq *BoundedQueue[E]) Iterator() Iterator[E] {
+	return bq.q.Iterator()
+}
+
+func (bq *BoundedQueue[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	return reverseSnapshotIterator[E](bq.q)
+}
+
+func (bq *BoundedQueue[E]) Size() int {
+	return bq.q.Size()
+}
+
+func (bq *BoundedQueue[E]) SnapshotIterator() Iterator[E] {
+	return snapshotIterator[E](bq.q)
+}
+
+func (bq *BoundedQueue[E]) ToSlice() []E {
+	return bq.q.ToSlice()
+}
+
+// En is Put without the error
+func (bq *BoundedQueue[E]) En(e E) {
+	_ = bq.Put(e)
+}
+
+func (bq *BoundedQueue[E]) De() (E, error) {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	e, err := bq.q.De()
+	if err == nil {
+		bq.notFull.signal()
+	}
+	return e, err
+}
+
+func (bq *BoundedQueue[E]) GetFront() (E, error) {
+	return bq.q.GetFront()
+}
+
+func (bq *BoundedQueue[E]) GetRear() (E, error) {
+	return bq.q.GetRear()
+}
+
+func (bq *BoundedQueue[E]) Remaining() int {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	return bq.capacity - bq.q.Size()
+}
+
+// Put adds e to the rear of the queue, applying the overflow policy if the queue is full.
+// It returns ErrQueueFull only under OverflowReject, and ErrQueueClosed after Close.
+func (bq *BoundedQueue[E]) Put(e E) error {
+	return bq.PutContext(context.Background(), e)
+}
+
+// PutContext is Put which stops waiting for space when ctx is done
+func (bq *BoundedQueue[E]) PutContext(ctx context.Context, e E) error {
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	ok, err := bq.admit(ctx, bq.q.Size, bq.dropOldest, ErrQueueFull, ErrQueueClosed)
+	if ok {
+		bq.q.En(e)
+	}
+	return err
+}
+
+func (bq *BoundedQueue[E]) String() string {
+	return fmt.Sprint(bq.q)
+}
@@ -0,0 +1,125 @@
+package container
+
+import (
+	"context"
+	"fmt"
+)
+
+// bottomRemover is a stack which can also remove its oldest element
+type bottomRemover[E any] interface {
+	Stack[E]
+	removeBottom() (E, error)
+}
+
+// BoundedStack is a stack which holds at most Capacity elements,
+// the oldest element of a stack is the one at the bottom.
+// A capacity less than 1 is treated as 1. The initial elements beyond the capacity
+// are discarded, unless the policy is OverflowDropOldest.
+type BoundedStack[E any] struct {
+	bounded
+	s bottomRemover[E]
+}
+
+func NewBoundedSliceStack[E any](capacity int, policy OverflowPolicy, es ...E) *BoundedStack[E] {
+	return newBoundedStack[E](NewSliceStack[E](), capacity, policy, es)
+}
+
+func NewBoundedLinkedStack[E any](capacity int, policy OverflowPolicy, es ...E) *BoundedStack[E] {
+	return newBoundedStack[E](NewLinkedStack[E](), capacity, policy, es)
+}
+
+func newBoundedStack[E any](s bottomRemover[E], capacity int, policy OverflowPolicy, es []E) *BoundedStack[E] {
+	bs := &BoundedStack[E]{s: s}
+	bs.init(capacity, policy)
+	for _, e := range es {
+		if bs.admitInit(bs.s.Size, bs.dropOldest) {
+			bs.s.Push(e)
+		}
+	}
+	return bs
+}
+
+func (bs *BoundedStack[E]) dropOldest() {
+	_, _ = bs.s.removeBottom()
+}
+
+func (bs *BoundedStack[E]) Clear() {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	bs.s.Clear()
+	bs.notFull.signal()
+}
+
+func (bs *BoundedStack[E]) Get(i int) (E, error) {
+	return bs.s.Get(i)
+}
+
+func (bs *BoundedStack[E]) IsEmpty() bool {
+	return bs.s.IsEmpty()
+}
+
+func (bs *BoundedStack[E]) Iterator() Iterator[E] {
+	return bs.s.Iterator()
+}
+
+func (bs *BoundedStack[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	return reverseSnapshotIterator[E](bs.s)
+}
+
+func (bs *BoundedStack[E]) Size() int {
+	return bs.s.Size()
+}
+
+func (bs *BoundedStack[E]) SnapshotIterator() Iterator[E] {
+	return snapshotIterator[E](bs.s)
+}
+
+func (bs *BoundedStack[E]) ToSlice() []E {
+	return bs.s.ToSlice()
+}
+
+func (bs *BoundedStack[E]) GetTop() (E, error) {
+	return bs.s.GetTop()
+}
+
+func (bs *BoundedStack[E]) Pop() (E, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	e, err := bs.s.Pop()
+	if err == nil {
+		bs.notFull.signal()
+	}
+	return e, err
+}
+
+// Push is Put without the error
+func (bs *BoundedStack[E]) Push(e E) {
+	_ = bs.Put(e)
+}
+
+func (bs *BoundedStack[E]) Remaining() int {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	return bs.capacity - bs.s.Size()
+}
+
+// Put pushes e onto the stack, applying the overflow policy if the stack is full.
+// It returns ErrStackFull only under OverflowReject, and ErrStackClosed after Close.
+func (bs *BoundedStack[E]) Put(e E) error {
+	return bs.PutContext(context.Background(), e)
+}
+
+// PutContext is Put which stops waiting for space when ctx is done
+func (bs *BoundedStack[E]) PutContext(ctx context.Context, e E) error {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	ok, err := bs.admit(ctx, bs.s.Size, bs.dropOldest, ErrStackFull, ErrStackClosed)
+	if ok {
+		bs.s.Push(e)
+	}
+	return err
+}
+
+func (bs *BoundedStack[E]) String() string {
+	return fmt.Sprint(bs.s)
+}
@@ -0,0 +1,65 @@
+package container
+
+// hashThreshold is the number of elements from which the bulk operations
+// look the elements up in a hash set instead of comparing them one by one
+const hashThreshold = 16
+
+// hashSet holds elements whose dynamic types are comparable, it is the set of defaultEqual
+type hashSet[E any] map[any]struct{}
+
+// newHashSet returns false if any element of es cannot be a map key
+func newHashSet[E any](es []E) (set hashSet[E], ok bool) {
+	defer func() {
+		if recover() != nil {
+			set, ok = nil, false
+		}
+	}()
+	set = make(hashSet[E], len(es))
+	for _, e := range es {
+		set[any(e)] = struct{}{}
+	}
+	return set, true
+}
+
+// has reports false for the elements which cannot be a map key, as == does
+// for the elements whose dynamic types differ
+func (set hashSet[E]) has(e E) (ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	_, ok = set[any(e)]
+	return ok
+}
+
+// matcher returns a function which reports whether e equals any element of es
+func (eq equality[E]) matcher(es []E) func(e E) bool {
+	if len(es) >= hashThreshold {
+		if eq.set != nil {
+			return eq.set(es)
+		}
+		if eq.equal == nil {
+			if set, ok := newHashSet(es); ok {
+				return set.has
+			}
+		}
+	}
+	return func(e E) bool {
+		return eq.indexOf(es, e) != NotFound
+	}
+}
+
+// containsAll reports whether every element of es is one of the elements of list
+func containsAll[E any](list, es []E, eq equality[E]) bool {
+	if len(es) == 0 {
+		return true
+	}
+	contains := eq.matcher(list)
+	for _, e := range es {
+		if !contains(e) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,42 @@
+package container
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// Codec converts elements to bytes and back for the containers which keep them outside memory
+type Codec[E any] interface {
+	Encode(e E) ([]byte, error)
+	Decode(data []byte) (E, error)
+}
+
+// GobCodec encodes every element with its own gob stream, so that each one can be decoded alone
+type GobCodec[E any] struct{}
+
+func (GobCodec[E]) Encode(e E) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&e); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (GobCodec[E]) Decode(data []byte) (E, error) {
+	var e E
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&e)
+	return e, err
+}
+
+type JSONCodec[E any] struct{}
+
+func (JSONCodec[E]) Encode(e E) ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (JSONCodec[E]) Decode(data []byte) (E, error) {
+	var e E
+	err := json.Unmarshal(data, &e)
+	return e, err
+}
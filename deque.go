@@ -0,0 +1,16 @@
+package container
+
+import "errors"
+
+// Deque is double-ended queue, elements can be added and removed at both ends
+type Deque[E any] interface {
+	Container[E]
+	PushFront(e E)
+	PushBack(e E)
+	PopFront() (E, error)
+	PopBack() (E, error)
+	PeekFront() (E, error)
+	PeekBack() (E, error)
+}
+
+var ErrDequeEmpty = errors.New("error: deque cannot be empty")
@@ -0,0 +1,302 @@
+//go:build go1.23
+
+package container
+
+import "iter"
+
+// All returns an iterator over the indexes and elements of a snapshot of c, which is
+// the SnapshotIterator of c if c is a Snapshotter and a copy of c otherwise.
+// So the loop body may modify c, and other goroutines may write to c meanwhile,
+// the iteration observes none of these writes. The snapshots of the linked containers
+// are read lazily, see Snapshotter. The iteration stops early if the snapshot iterator
+// reports an error, such as a stale SubList view or a spill file of a SpillQueue which cannot be read,
+// the Err method of these containers reports it.
+func All[E any](c Container[E]) iter.Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		walk(snapshotIterator(c), func(k int) int {
+			return k
+		}, yield)
+	}
+}
+
+// Values is All without the indexes
+func Values[E any](c Container[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		walk(snapshotIterator(c), func(k int) int {
+			return k
+		}, func(_ int, e E) bool {
+			return yield(e)
+		})
+	}
+}
+
+// Backward is All in reverse order
+func Backward[E any](c Container[E]) iter.Seq2[int, E] {
+	return func(yield func(int, E) bool) {
+		it, n := reverseSnapshotIterator(c)
+		walk(it, func(k int) int {
+			return n - 1 - k
+		}, yield)
+	}
+}
+
+// walk yields the elements of it with the indexes computed from their positions k,
+// it stops at the first error of it
+func walk[E any](it Iterator[E], index func(k int) int, yield func(int, E) bool) {
+	for k := 0; it.HasNext(); k++ {
+		e := it.Next()
+		if it.Err() != nil || !yield(index(k), e) {
+			return
+		}
+	}
+}
+
+// Seq adapts it to iter.Seq, the iteration stops early if it reports an error
+func Seq[E any](it Iterator[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for it.HasNext() {
+			if !yield(it.Next()) {
+				return
+			}
+		}
+	}
+}
+
+// PullIterator adapts iter.Seq to Iterator with iter.Pull.
+// Stop must be called if the iteration is abandoned before HasNext returns false.
+type PullIterator[E any] struct {
+	next    func() (E, bool)
+	stop    func()
+	e       E
+	ok      bool
+	fetched bool
+}
+
+func FromSeq[E any](seq iter.Seq[E]) *PullIterator[E] {
+	next, stop := iter.Pull(seq)
+	return &PullIterator[E]{
+		next: next,
+		stop: stop,
+	}
+}
+
+func (it *PullIterator[E]) fetch() {
+	if !it.fetched {
+		it.e, it.ok = it.next()
+		it.fetched = true
+	}
+}
+
+func (it *PullIterator[E]) HasNext() bool {
+	it.fetch()
+	return it.ok
+}
+
+func (it *PullIterator[E]) Next() E {
+	it.fetch()
+	it.fetched = false
+	e := it.e
+	var zero E
+	it.e = zero
+	return e
+}
+
+func (it *PullIterator[E]) Err() error {
+	return nil
+}
+
+func (it *PullIterator[E]) Stop() {
+	it.stop()
+}
+
+func (sl *SliceList[E]) All() iter.Seq2[int, E] {
+	return All[E](sl)
+}
+
+func (sl *SliceList[E]) Values() iter.Seq[E] {
+	return Values[E](sl)
+}
+
+func (sl *SliceList[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](sl)
+}
+
+func (ll *LinkedList[E]) All() iter.Seq2[int, E] {
+	return All[E](ll)
+}
+
+func (ll *LinkedList[E]) Values() iter.Seq[E] {
+	return Values[E](ll)
+}
+
+func (ll *LinkedList[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](ll)
+}
+
+func (sl *SortedList[E]) All() iter.Seq2[int, E] {
+	return All[E](sl)
+}
+
+func (sl *SortedList[E]) Values() iter.Seq[E] {
+	return Values[E](sl)
+}
+
+func (sl *SortedList[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](sl)
+}
+
+func (sl *subList[E]) All() iter.Seq2[int, E] {
+	return All[E](sl)
+}
+
+func (sl *subList[E]) Values() iter.Seq[E] {
+	return Values[E](sl)
+}
+
+func (sl *subList[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](sl)
+}
+
+func (sq *SliceQueue[E]) All() iter.Seq2[int, E] {
+	return All[E](sq)
+}
+
+func (sq *SliceQueue[E]) Values() iter.Seq[E] {
+	return Values[E](sq)
+}
+
+func (sq *SliceQueue[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](sq)
+}
+
+func (lq *LinkedQueue[E]) All() iter.Seq2[int, E] {
+	return All[E](lq)
+}
+
+func (lq *LinkedQueue[E]) Values() iter.Seq[E] {
+	return Values[E](lq)
+}
+
+func (lq *LinkedQueue[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](lq)
+}
+
+func (ss *SliceStack[E]) All() iter.Seq2[int, E] {
+	return All[E](ss)
+}
+
+func (ss *SliceStack[E]) Values() iter.Seq[E] {
+	return Values[E](ss)
+}
+
+func (ss *SliceStack[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](ss)
+}
+
+func (ls *LinkedStack[E]) All() iter.Seq2[int, E] {
+	return All[E](ls)
+}
+
+func (ls *LinkedStack[E]) Values() iter.Seq[E] {
+	return Values[E](ls)
+}
+
+func (ls *LinkedStack[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](ls)
+}
+
+func (sd *SliceDeque[E]) All() iter.Seq2[int, E] {
+	return All[E](sd)
+}
+
+func (sd *SliceDeque[E]) Values() iter.Seq[E] {
+	return Values[E](sd)
+}
+
+func (sd *SliceDeque[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](sd)
+}
+
+func (ld *LinkedDeque[E]) All() iter.Seq2[int, E] {
+	return All[E](ld)
+}
+
+func (ld *LinkedDeque[E]) Values() iter.Seq[E] {
+	return Values[E](ld)
+}
+
+func (ld *LinkedDeque[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](ld)
+}
+
+func (pq *PriorityQueue[E]) All() iter.Seq2[int, E] {
+	return All[E](pq)
+}
+
+func (pq *PriorityQueue[E]) Values() iter.Seq[E] {
+	return Values[E](pq)
+}
+
+func (pq *PriorityQueue[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](pq)
+}
+
+func (bq *blockingQueue[E]) All() iter.Seq2[int, E] {
+	return All[E](bq)
+}
+
+func (bq *blockingQueue[E]) Values() iter.Seq[E] {
+	return Values[E](bq)
+}
+
+func (bq *blockingQueue[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](bq)
+}
+
+func (bq *BoundedQueue[E]) All() iter.Seq2[int, E] {
+	return All[E](bq)
+}
+
+func (bq *BoundedQueue[E]) Values() iter.Seq[E] {
+	return Values[E](bq)
+}
+
+func (bq *BoundedQueue[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](bq)
+}
+
+func (bs *BoundedStack[E]) All() iter.Seq2[int, E] {
+	return All[E](bs)
+}
+
+func (bs *BoundedStack[E]) Values() iter.Seq[E] {
+	return Values[E](bs)
+}
+
+func (bs *BoundedStack[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](bs)
+}
+
+func (pq *PersistentQueue[E]) All() iter.Seq2[int, E] {
+	return All[E](pq)
+}
+
+func (pq *PersistentQueue[E]) Values() iter.Seq[E] {
+	return Values[E](pq)
+}
+
+func (pq *PersistentQueue[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](pq)
+}
+
+func (sq *SpillQueue[E]) All() iter.Seq2[int, E] {
+	return All[E](sq)
+}
+
+func (sq *SpillQueue[E]) Values() iter.Seq[E] {
+	return Values[E](sq)
+}
+
+func (sq *SpillQueue[E]) Backward() iter.Seq2[int, E] {
+	return Backward[E](sq)
+}
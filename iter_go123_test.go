@@ -0,0 +1,91 @@
+//go:build go1.23
+
+package container
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestValuesConcurrentWriter(t *testing.T) {
+	q := NewLinkedQueue(0, 1, 2)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 3; i < 1000; i++ {
+			q.En(i)
+		}
+	}()
+	for k := 0; k < 10; k++ {
+		want := 0
+		for e := range Values[int](q) {
+			if e != want {
+				t.Fatalf("element %d = %v", want, e)
+			}
+			want++
+		}
+	}
+	wg.Wait()
+}
+
+func TestAllLoopBodyWrites(t *testing.T) {
+	ll := NewLinkedList(1, 2, 3)
+	var got []int
+	for i, e := range ll.All() {
+		got = append(got, i, e)
+		ll.Add(e)
+	}
+	if !reflect.DeepEqual(got, []int{0, 1, 1, 2, 2, 3}) {
+		t.Fatalf("All = %v, want [0 1 1 2 2 3]", got)
+	}
+	if got := ll.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3, 1, 2, 3}) {
+		t.Fatalf("list = %v after the loop", got)
+	}
+}
+
+func TestBackward(t *testing.T) {
+	tests := []struct {
+		name string
+		c    Container[int]
+	}{
+		{"SliceList", NewSliceList(1, 2, 3, 4, 5, 6, 7)},
+		{"LinkedList", NewLinkedList(1, 2, 3, 4, 5, 6, 7)},
+		{"LinkedDeque", NewLinkedDeque(1, 2, 3, 4, 5, 6, 7)},
+		{"SpillQueue", newTestSpillQueue(t, 1, 2, 3, 4, 5, 6, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var indexes, es []int
+			for i, e := range Backward(tt.c) {
+				indexes = append(indexes, i)
+				es = append(es, e)
+			}
+			if !reflect.DeepEqual(indexes, []int{6, 5, 4, 3, 2, 1, 0}) {
+				t.Fatalf("indexes = %v", indexes)
+			}
+			if !reflect.DeepEqual(es, []int{7, 6, 5, 4, 3, 2, 1}) {
+				t.Fatalf("elements = %v", es)
+			}
+		})
+	}
+}
+
+func TestValuesStopsAtSpillError(t *testing.T) {
+	sq := newTestSpillQueue(t, 1, 2, 3, 4, 5, 6, 7, 8)
+	sq.cachePath, sq.cache = "", nil
+	for _, chunk := range sq.chunks {
+		sq.removeChunk(chunk)
+	}
+	n := 0
+	for range Values[int](sq) {
+		n++
+	}
+	if n == 8 {
+		t.Fatal("Values yielded every element although the spill files are gone")
+	}
+	if sq.Err() == nil {
+		t.Fatal("Err = nil after a spill file could not be read")
+	}
+}
@@ -1,10 +1,18 @@
 package container
 
-import "errors"
+import (
+	"errors"
+	"sync"
+)
 
+// Iterator visits the elements of a container.
+// Once HasNext returns false, Err reports whether the iteration has stopped because of an error,
+// such as ErrConcurrentModification when the container has been structurally modified
+// other than through the iterator.
 type Iterator[E any] interface {
 	HasNext() bool
 	Next() E
+	Err() error
 }
 
 type Container[E any] interface {
@@ -16,87 +24,394 @@ type Container[E any] interface {
 	ToSlice() []E
 }
 
+// Snapshotter is implemented by the containers which can iterate a point-in-time view of their elements.
+// The containers of this package implement it, the slice based ones by copying their elements,
+// and the linked ones by walking their nodes until they are modified.
+type Snapshotter[E any] interface {
+	// SnapshotIterator iterates the elements as they are when it is called,
+	// so unlike Iterator it never observes writes made meanwhile
+	SnapshotIterator() Iterator[E]
+}
+
+// snapshotIterator returns the snapshot iterator of c, or iterates a copy of c if it is not a Snapshotter
+func snapshotIterator[E any](c Container[E]) Iterator[E] {
+	if s, ok := c.(Snapshotter[E]); ok {
+		return s.SnapshotIterator()
+	}
+	return NewSliceIterator[E](c.ToSlice())
+}
+
 var (
-	ErrIndexGtSize  = errors.New("error: param i cannot be greater than the container size")
-	ErrIndexGteSize = errors.New("error: param i cannot be greater than or equal to the container size")
-	ErrIndexLtZero  = errors.New("error: param i cannot be less than 0")
+	ErrConcurrentModification = errors.New("error: container has been structurally modified concurrently")
+	ErrIndexGtSize            = errors.New("error: param i cannot be greater than the container size")
+	ErrIndexGteSize           = errors.New("error: param i cannot be greater than or equal to the container size")
+	ErrIndexLtZero            = errors.New("error: param i cannot be less than 0")
 )
 
 // SqContainer is sequence container
 // All implementations must embed UnimplementedSqContainer
-type SqContainer[E comparable] interface {
+type SqContainer[E any] interface {
 	Get(i int) (E, error)
+	ModCount() int
 	Size() int
 	mustEmbedUnimplementedSqContainer()
 }
 
-type UnimplementedSqContainer[E comparable] struct{}
+type UnimplementedSqContainer[E any] struct{}
 
 func (UnimplementedSqContainer[E]) Get(i int) (E, error) {
 	var e E
 	return e, errors.New("method Get not implemented")
 }
 
+func (UnimplementedSqContainer[E]) ModCount() int {
+	return 0
+}
+
 func (UnimplementedSqContainer[E]) Size() int {
 	return 0
 }
 
 func (UnimplementedSqContainer[E]) mustEmbedUnimplementedSqContainer() {}
 
-type SqIterator[E comparable] struct {
-	sc    SqContainer[E]
-	index int
+type SqIterator[E any] struct {
+	sc       SqContainer[E]
+	index    int
+	modCount int
+	err      error
+}
+
+func NewSqIterator[E any](sc SqContainer[E]) *SqIterator[E] {
+	return &SqIterator[E]{
+		sc:       sc,
+		modCount: sc.ModCount(),
+	}
 }
 
-func NewSqIterator[E comparable](sc SqContainer[E]) *SqIterator[E] {
-	return &SqIterator[E]{sc: sc}
+func (it *SqIterator[E]) check() bool {
+	if it.err == nil && it.sc.ModCount() != it.modCount {
+		it.err = ErrConcurrentModification
+	}
+	return it.err == nil
 }
 
 func (it *SqIterator[E]) HasNext() bool {
-	return it.index < it.sc.Size()
+	return it.check() && it.index < it.sc.Size()
 }
 
 func (it *SqIterator[E]) Next() E {
-	e, _ := it.sc.Get(it.index)
+	var e E
+	if !it.check() {
+		return e
+	}
+	e, err := it.sc.Get(it.index)
+	if err != nil {
+		it.err = err
+		return e
+	}
+	it.index++
+	return e
+}
+
+func (it *SqIterator[E]) Err() error {
+	return it.err
+}
+
+// SliceIterator iterates the elements of a slice in order
+type SliceIterator[E any] struct {
+	es    []E
+	index int
+}
+
+func NewSliceIterator[E any](es []E) *SliceIterator[E] {
+	return &SliceIterator[E]{es: es}
+}
+
+func (it *SliceIterator[E]) HasNext() bool {
+	return it.index < len(it.es)
+}
+
+func (it *SliceIterator[E]) Next() E {
+	var e E
+	if it.index >= len(it.es) {
+		return e
+	}
+	e = it.es[it.index]
 	it.index++
 	return e
 }
 
+func (it *SliceIterator[E]) Err() error {
+	return nil
+}
+
 // LinkedContainer is linked container
 // All implementations must embed UnimplementedLinkedContainer
-type LinkedContainer[E comparable] interface {
+type LinkedContainer[E any] interface {
 	Head() *LinkedNode[E]
+	ModCount() int
+	// linkedState returns the lock of the container and its modification count,
+	// which LinkedIterator reads under the lock, or nil if the container does not share them
+	linkedState() (*sync.RWMutex, *int)
 	mustEmbedUnimplementedLinkedContainer()
 }
 
-type UnimplementedLinkedContainer[E comparable] struct{}
+type UnimplementedLinkedContainer[E any] struct{}
 
 func (UnimplementedLinkedContainer[E]) Head() *LinkedNode[E] {
 	return nil
 }
 
+func (UnimplementedLinkedContainer[E]) ModCount() int {
+	return 0
+}
+
+func (UnimplementedLinkedContainer[E]) linkedState() (*sync.RWMutex, *int) {
+	return nil, nil
+}
+
 func (UnimplementedLinkedContainer[E]) mustEmbedUnimplementedLinkedContainer() {}
 
-type LinkedIterator[E comparable] struct {
-	lc      LinkedContainer[E]
-	curNode *LinkedNode[E]
+// LinkedIterator walks the nodes of a LinkedContainer.
+// If the container shares its lock, the modification check and the walk happen under it.
+type LinkedIterator[E any] struct {
+	lc       LinkedContainer[E]
+	head     *LinkedNode[E]
+	curNode  *LinkedNode[E]
+	rw       *sync.RWMutex
+	mc       *int
+	modCount int
+	err      error
+}
+
+func NewLinkedIterator[E any](lc LinkedContainer[E]) *LinkedIterator[E] {
+	l := &LinkedIterator[E]{
+		lc:       lc,
+		head:     lc.Head(),
+		modCount: lc.ModCount(),
+	}
+	l.curNode = l.head
+	l.rw, l.mc = lc.linkedState()
+	return l
+}
+
+func (l *LinkedIterator[E]) lock() {
+	if l.rw != nil {
+		l.rw.RLock()
+	}
 }
 
-func NewLinkedIterator[E comparable](lc LinkedContainer[E]) *LinkedIterator[E] {
-	return &LinkedIterator[E]{
-		lc:      lc,
-		curNode: lc.Head(),
+func (l *LinkedIterator[E]) unlock() {
+	if l.rw != nil {
+		l.rw.RUnlock()
 	}
 }
 
+// check must be called with the lock held, before following the links of curNode,
+// which may have been unlinked
+func (l *LinkedIterator[E]) check() bool {
+	if l.err != nil {
+		return false
+	}
+	modCount := 0
+	if l.mc != nil {
+		modCount = *l.mc
+	} else {
+		modCount = l.lc.ModCount()
+	}
+	if modCount != l.modCount {
+		l.err = ErrConcurrentModification
+	}
+	return l.err == nil
+}
+
 func (l *LinkedIterator[E]) HasNext() bool {
-	return l.curNode.Next() != l.lc.Head()
+	l.lock()
+	defer l.unlock()
+	return l.check() && l.curNode.next != l.head
 }
 
 func (l *LinkedIterator[E]) Next() E {
-	next := l.curNode.Next()
-	if next != l.lc.Head() {
+	l.lock()
+	defer l.unlock()
+	var e E
+	if !l.check() {
+		return e
+	}
+	next := l.curNode.next
+	if next != l.head {
 		l.curNode = next
 	}
-	return next.Value()
+	return next.e
+}
+
+func (l *LinkedIterator[E]) Err() error {
+	return l.err
+}
+
+// reverseSnapshotter is implemented by the containers which iterate a snapshot backwards
+// without copying it, the iterator comes with the number of elements it visits
+type reverseSnapshotter[E any] interface {
+	reverseSnapshotIterator() (Iterator[E], int)
+}
+
+// reverseSnapshotIterator iterates a snapshot of c from the last element,
+// it copies c unless c is a reverseSnapshotter
+func reverseSnapshotIterator[E any](c Container[E]) (Iterator[E], int) {
+	if s, ok := c.(reverseSnapshotter[E]); ok {
+		return s.reverseSnapshotIterator()
+	}
+	es := reverse(c.ToSlice())
+	return NewSliceIterator[E](es), len(es)
+}
+
+// reverse reverses es in place
+func reverse[E any](es []E) []E {
+	for i, j := 0, len(es)-1; i < j; i, j = i+1, j-1 {
+		es[i], es[j] = es[j], es[i]
+	}
+	return es
+}
+
+// snapshots are the unfinished snapshot iterators walking the nodes of a linked container.
+// add and remove must be called with the lock of the container held for reading at least,
+// and preserve with the lock held for writing.
+type snapshots[E any] struct {
+	mu  sync.Mutex
+	its map[*linkedSnapshotIterator[E]]struct{}
+}
+
+func (s *snapshots[E]) add(it *linkedSnapshotIterator[E]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.its == nil {
+		s.its = make(map[*linkedSnapshotIterator[E]]struct{})
+	}
+	s.its[it] = struct{}{}
+}
+
+func (s *snapshots[E]) remove(it *linkedSnapshotIterator[E]) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.its, it)
+}
+
+// preserve must be called before the container modifies its nodes or their elements,
+// it makes every snapshot iterator copy the rest of its walk
+func (s *snapshots[E]) preserve() {
+	// the writers hold the lock exclusively, so no iterator is added or removed meanwhile
+	if len(s.its) == 0 {
+		return
+	}
+	for it := range s.its {
+		it.preserve()
+	}
+	s.its = nil
+}
+
+// linkedSnapshotIterator is a versioned walk over n nodes of a linked container. It walks the nodes
+// as long as the container is not modified, the container makes it copy the rest of the walk
+// before its first modification. So the iteration costs no copy if there is no writer meanwhile.
+// The version is the modification count of the container, if it changes while the iterator
+// has not copied its walk the iterator stops with ErrConcurrentModification.
+type linkedSnapshotIterator[E any] struct {
+	rw      *sync.RWMutex
+	snaps   *snapshots[E]
+	mc      *int
+	version int
+	next    *LinkedNode[E]
+	left    int
+	reverse bool
+	rest    []E
+	copied  bool
+	err     error
+}
+
+// newLinkedSnapshotIterator must be called with rw held for reading, first is the first node of the walk
+func newLinkedSnapshotIterator[E any](rw *sync.RWMutex, mc *int, snaps *snapshots[E], first *LinkedNode[E], n int) *linkedSnapshotIterator[E] {
+	it := &linkedSnapshotIterator[E]{
+		rw:      rw,
+		snaps:   snaps,
+		mc:      mc,
+		version: *mc,
+		next:    first,
+		left:    n,
+	}
+	if n > 0 {
+		snaps.add(it)
+	}
+	return it
+}
+
+// newReverseLinkedSnapshotIterator walks the prev links from last,
+// it must be called with rw held for reading
+func newReverseLinkedSnapshotIterator[E any](rw *sync.RWMutex, mc *int, snaps *snapshots[E], last *LinkedNode[E], n int) *linkedSnapshotIterator[E] {
+	it := newLinkedSnapshotIterator[E](rw, mc, snaps, last, n)
+	it.reverse = true
+	return it
+}
+
+// step returns the node of the walk after node
+func (it *linkedSnapshotIterator[E]) step(node *LinkedNode[E]) *LinkedNode[E] {
+	if it.reverse {
+		return node.prev
+	}
+	return node.next
+}
+
+// preserve is called by snapshots.preserve
+func (it *linkedSnapshotIterator[E]) preserve() {
+	it.rest = make([]E, 0, it.left)
+	for ; it.left > 0; it.left-- {
+		it.rest = append(it.rest, it.next.e)
+		it.next = it.step(it.next)
+	}
+	it.next = nil
+	it.copied = true
+}
+
+func (it *linkedSnapshotIterator[E]) HasNext() bool {
+	it.rw.RLock()
+	defer it.rw.RUnlock()
+	if it.copied {
+		return len(it.rest) > 0
+	}
+	return it.check() && it.left > 0
+}
+
+// check must be called with the lock held, before following the links of the nodes
+func (it *linkedSnapshotIterator[E]) check() bool {
+	if it.err == nil && *it.mc != it.version {
+		it.err = ErrConcurrentModification
+		it.left = 0
+		it.snaps.remove(it)
+	}
+	return it.err == nil
+}
+
+func (it *linkedSnapshotIterator[E]) Next() E {
+	it.rw.RLock()
+	defer it.rw.RUnlock()
+	var e E
+	if it.copied {
+		if len(it.rest) > 0 {
+			e, it.rest[0] = it.rest[0], e
+			it.rest = it.rest[1:]
+		}
+		return e
+	}
+	if !it.check() || it.left == 0 {
+		return e
+	}
+	e = it.next.e
+	it.next = it.step(it.next)
+	if it.left--; it.left == 0 {
+		it.next = nil
+		it.snaps.remove(it)
+	}
+	return e
+}
+
+func (it *linkedSnapshotIterator[E]) Err() error {
+	return it.err
 }
@@ -0,0 +1,385 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+)
+
+// The containers are encoded as JSON arrays of their elements in iteration order,
+// the stacks from the bottom to the top. Decoding replaces the elements of the container.
+
+var (
+	ErrNoLess         = errors.New("error: less function cannot be nil")
+	ErrNotConstructed = errors.New("error: container cannot be used before a constructor creates it")
+)
+
+// marshalJSON encodes an empty container as [] rather than null
+func marshalJSON[E any](es []E) ([]byte, error) {
+	if es == nil {
+		es = []E{}
+	}
+	return json.Marshal(es)
+}
+
+func unmarshalJSON[E any](data []byte) ([]E, error) {
+	var es []E
+	if err := json.Unmarshal(data, &es); err != nil {
+		return nil, err
+	}
+	return es, nil
+}
+
+// writeJSON writes the elements of the ring of head one at a time,
+// the ring of a zero value container has not been linked yet
+func writeJSON[E any](w io.Writer, head *LinkedNode[E]) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	for node := head.next; node != nil && node != head; node = node.next {
+		if node != head.next {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		b, err := json.Marshal(node.e)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+func marshalLinkedJSON[E any](head *LinkedNode[E]) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, head); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (sl *SliceList[E]) MarshalJSON() ([]byte, error) {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return marshalJSON(sl.elems)
+}
+
+func (sl *SliceList[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.reset(es)
+	return nil
+}
+
+func (ll *LinkedList[E]) MarshalJSON() ([]byte, error) {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return marshalLinkedJSON(&ll.head)
+}
+
+// WriteJSON encodes the list to w one element at a time, holding the read lock until it returns
+func (ll *LinkedList[E]) WriteJSON(w io.Writer) error {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return writeJSON(w, &ll.head)
+}
+
+func (ll *LinkedList[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	ll.reset(es)
+	return nil
+}
+
+func (sl *SortedList[E]) MarshalJSON() ([]byte, error) {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return marshalJSON(sl.elems)
+}
+
+// UnmarshalJSON sorts the decoded elements, it returns ErrNoLess
+// if the list has not been created by a constructor
+func (sl *SortedList[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	if sl.less == nil {
+		return ErrNoLess
+	}
+	sort.Stable(sortSlice[E]{es: es, less: sl.less})
+	sl.elems = es
+	sl.modCount++
+	return nil
+}
+
+func (sq *SliceQueue[E]) MarshalJSON() ([]byte, error) {
+	sq.rw.RLock()
+	defer sq.rw.RUnlock()
+	return marshalJSON(sq.ring.toSlice())
+}
+
+func (sq *SliceQueue[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	sq.reset(es)
+	return nil
+}
+
+func (lq *LinkedQueue[E]) MarshalJSON() ([]byte, error) {
+	lq.rw.RLock()
+	defer lq.rw.RUnlock()
+	return marshalLinkedJSON(&lq.head)
+}
+
+// WriteJSON encodes the queue to w one element at a time, holding the read lock until it returns
+func (lq *LinkedQueue[E]) WriteJSON(w io.Writer) error {
+	lq.rw.RLock()
+	defer lq.rw.RUnlock()
+	return writeJSON(w, &lq.head)
+}
+
+func (lq *LinkedQueue[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	lq.rw.Lock()
+	defer lq.rw.Unlock()
+	lq.reset(es)
+	return nil
+}
+
+// MarshalJSON encodes the stack from the bottom to the top
+func (ss *SliceStack[E]) MarshalJSON() ([]byte, error) {
+	ss.rw.RLock()
+	defer ss.rw.RUnlock()
+	return marshalJSON(ss.elems)
+}
+
+// UnmarshalJSON pushes the elements in order, the last one ends up on the top
+func (ss *SliceStack[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	ss.rw.Lock()
+	defer ss.rw.Unlock()
+	ss.reset(es)
+	return nil
+}
+
+// MarshalJSON encodes the stack from the bottom to the top
+func (ls *LinkedStack[E]) MarshalJSON() ([]byte, error) {
+	ls.rw.RLock()
+	defer ls.rw.RUnlock()
+	return marshalLinkedJSON(&ls.head)
+}
+
+// WriteJSON encodes the stack to w from the bottom to the top one element at a time,
+// holding the read lock until it returns
+func (ls *LinkedStack[E]) WriteJSON(w io.Writer) error {
+	ls.rw.RLock()
+	defer ls.rw.RUnlock()
+	return writeJSON(w, &ls.head)
+}
+
+// UnmarshalJSON pushes the elements in order, the last one ends up on the top
+func (ls *LinkedStack[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	ls.rw.Lock()
+	defer ls.rw.Unlock()
+	ls.reset(es)
+	return nil
+}
+
+func (sd *SliceDeque[E]) MarshalJSON() ([]byte, error) {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	return marshalJSON(sd.ring.toSlice())
+}
+
+func (sd *SliceDeque[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	sd.rw.Lock()
+	defer sd.rw.Unlock()
+	sd.ring = newRingBuffer(es)
+	sd.modCount++
+	return nil
+}
+
+func (ld *LinkedDeque[E]) MarshalJSON() ([]byte, error) {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	return marshalLinkedJSON(&ld.head)
+}
+
+// WriteJSON encodes the deque to w one element at a time, holding the read lock until it returns
+func (ld *LinkedDeque[E]) WriteJSON(w io.Writer) error {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	return writeJSON(w, &ld.head)
+}
+
+func (ld *LinkedDeque[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	ld.rw.Lock()
+	defer ld.rw.Unlock()
+	ld.init()
+	for _, e := range es {
+		ld.insertBefore(&ld.head, e)
+	}
+	return nil
+}
+
+// MarshalJSON encodes the elements in the order of the heap, not in priority order
+func (pq *PriorityQueue[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSON(pq.ToSlice())
+}
+
+// UnmarshalJSON invalidates the handles of the previous elements, it returns ErrNoLess
+// if the queue has not been created by a constructor
+func (pq *PriorityQueue[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	if pq.less == nil {
+		return ErrNoLess
+	}
+	pq.reset(es)
+	return nil
+}
+
+// marshalWrappedJSON encodes the inner container of a wrapper, which is nil for a zero value wrapper
+func marshalWrappedJSON[E any](c Container[E]) ([]byte, error) {
+	if c == nil {
+		return marshalJSON[E](nil)
+	}
+	return json.Marshal(c)
+}
+
+func (bq *blockingQueue[E]) MarshalJSON() ([]byte, error) {
+	return marshalWrappedJSON[E](bq.q)
+}
+
+// UnmarshalJSON wakes up the waiters. It returns ErrQueueFull without changing the queue
+// if there are more elements than the capacity, and ErrNotConstructed
+// if the queue has not been created by a constructor.
+func (bq *blockingQueue[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.q == nil {
+		return ErrNotConstructed
+	}
+	if bq.capacity > 0 && len(es) > bq.capacity {
+		return ErrQueueFull
+	}
+	bq.q.Clear()
+	for _, e := range es {
+		bq.q.En(e)
+	}
+	bq.signal()
+	return nil
+}
+
+func (bq *BoundedQueue[E]) MarshalJSON() ([]byte, error) {
+	return marshalWrappedJSON[E](bq.q)
+}
+
+// UnmarshalJSON discards the elements beyond the capacity as the constructors do,
+// it returns ErrNotConstructed if the queue has not been created by a constructor
+func (bq *BoundedQueue[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	bq.mu.Lock()
+	defer bq.mu.Unlock()
+	if bq.q == nil {
+		return ErrNotConstructed
+	}
+	bq.q.Clear()
+	for _, e := range es {
+		if bq.admitInit(bq.q.Size, bq.dropOldest) {
+			bq.q.En(e)
+		}
+	}
+	bq.notFull.signal()
+	return nil
+}
+
+// MarshalJSON encodes the stack from the bottom to the top
+func (bs *BoundedStack[E]) MarshalJSON() ([]byte, error) {
+	return marshalWrappedJSON[E](bs.s)
+}
+
+// UnmarshalJSON discards the elements beyond the capacity as the constructors do,
+// it returns ErrNotConstructed if the stack has not been created by a constructor
+func (bs *BoundedStack[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+	if bs.s == nil {
+		return ErrNotConstructed
+	}
+	bs.s.Clear()
+	for _, e := range es {
+		if bs.admitInit(bs.s.Size, bs.dropOldest) {
+			bs.s.Push(e)
+		}
+	}
+	bs.notFull.signal()
+	return nil
+}
+
+func (sl *subList[E]) MarshalJSON() ([]byte, error) {
+	return marshalJSON(sl.ToSlice())
+}
+
+// UnmarshalJSON replaces the elements of the view, and so the ones of the list
+func (sl *subList[E]) UnmarshalJSON(data []byte) error {
+	es, err := unmarshalJSON[E](data)
+	if err != nil {
+		return err
+	}
+	return sl.ReplaceRange(0, sl.Size(), es)
+}
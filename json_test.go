@@ -0,0 +1,158 @@
+package container
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	view, _ := NewLinkedList(0, 1, 2, 3, 4).SubList(1, 4)
+	tests := []struct {
+		name     string
+		src, dst Container[int]
+	}{
+		{"SliceList", NewSliceList(1, 2, 3), NewSliceList(9)},
+		{"LinkedList", NewLinkedList(1, 2, 3), NewLinkedList(9)},
+		{"SortedList", NewSortedList(3, 1, 2), NewSortedList(9)},
+		{"SliceQueue", NewSliceQueue(1, 2, 3), NewSliceQueue(9)},
+		{"LinkedQueue", NewLinkedQueue(1, 2, 3), NewLinkedQueue(9)},
+		{"SliceStack", NewSliceStack(1, 2, 3), NewSliceStack(9)},
+		{"LinkedStack", NewLinkedStack(1, 2, 3), NewLinkedStack(9)},
+		{"SliceDeque", NewSliceDeque(1, 2, 3), NewSliceDeque(9)},
+		{"LinkedDeque", NewLinkedDeque(1, 2, 3), NewLinkedDeque(9)},
+		{"PriorityQueue", NewPriorityQueue(Less[int], 3, 1, 2), NewPriorityQueue(Less[int], 9)},
+		{"SliceBlockingQueue", NewSliceBlockingQueue(5, 1, 2, 3), NewSliceBlockingQueue(5, 9)},
+		{"LinkedBlockingQueue", NewLinkedBlockingQueue(0, 1, 2, 3), NewLinkedBlockingQueue(0, 9)},
+		{"BoundedQueue", NewBoundedSliceQueue(5, OverflowReject, 1, 2, 3), NewBoundedLinkedQueue(5, OverflowReject, 9)},
+		{"BoundedStack", NewBoundedLinkedStack(5, OverflowReject, 1, 2, 3), NewBoundedSliceStack(5, OverflowReject, 9)},
+		{"SubList", view, NewSliceList(9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(data, tt.dst); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := tt.dst.ToSlice(), tt.src.ToSlice(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("decoded %s into %v, want %v", data, got, want)
+			}
+		})
+	}
+}
+
+func TestJSONZeroValues(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  Container[int]
+	}{
+		{"SliceList", new(SliceList[int])},
+		{"LinkedList", new(LinkedList[int])},
+		{"SliceQueue", new(SliceQueue[int])},
+		{"LinkedQueue", new(LinkedQueue[int])},
+		{"SliceStack", new(SliceStack[int])},
+		{"LinkedStack", new(LinkedStack[int])},
+		{"SliceDeque", new(SliceDeque[int])},
+		{"LinkedDeque", new(LinkedDeque[int])},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "[]" {
+				t.Fatalf("encoded the zero value as %s, want []", data)
+			}
+			if err := json.Unmarshal([]byte("[1,2,3]"), tt.dst); err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.dst.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+				t.Fatalf("decoded %v, want [1 2 3]", got)
+			}
+		})
+	}
+}
+
+func TestJSONZeroValueErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		dst  json.Unmarshaler
+		want error
+	}{
+		{"SortedList", new(SortedList[int]), ErrNoLess},
+		{"PriorityQueue", new(PriorityQueue[int]), ErrNoLess},
+		{"SliceBlockingQueue", new(SliceBlockingQueue[int]), ErrNotConstructed},
+		{"LinkedBlockingQueue", new(LinkedBlockingQueue[int]), ErrNotConstructed},
+		{"BoundedQueue", new(BoundedQueue[int]), ErrNotConstructed},
+		{"BoundedStack", new(BoundedStack[int]), ErrNotConstructed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.dst)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(data) != "[]" {
+				t.Fatalf("encoded the zero value as %s, want []", data)
+			}
+			if err := tt.dst.UnmarshalJSON([]byte("[1]")); !errors.Is(err, tt.want) {
+				t.Fatalf("UnmarshalJSON = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestJSONStackOrder(t *testing.T) {
+	for _, s := range []Stack[int]{NewSliceStack[int](), NewLinkedStack[int]()} {
+		s.Push(1)
+		s.Push(2)
+		s.Push(3)
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != "[1,2,3]" {
+			t.Fatalf("%T encoded as %s, want the bottom first", s, data)
+		}
+		if err := json.Unmarshal(data, s); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []int{3, 2, 1} {
+			if got, err := s.Pop(); err != nil || got != want {
+				t.Fatalf("%T Pop = %v, %v, want %v", s, got, err, want)
+			}
+		}
+	}
+}
+
+func TestJSONBlockingQueueCapacity(t *testing.T) {
+	bq := NewSliceBlockingQueue(2, 7)
+	if err := json.Unmarshal([]byte("[1,2,3]"), bq); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Unmarshal beyond the capacity = %v, want ErrQueueFull", err)
+	}
+	if got := bq.ToSlice(); !reflect.DeepEqual(got, []int{7}) {
+		t.Fatalf("the failed Unmarshal changed the queue to %v", got)
+	}
+	if err := json.Unmarshal([]byte("[1,2]"), bq); err != nil {
+		t.Fatal(err)
+	}
+	if got := bq.ToSlice(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("decoded %v, want [1 2]", got)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewLinkedList("a", "b").WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != `["a","b"]` {
+		t.Fatalf("WriteJSON wrote %s", buf.String())
+	}
+}
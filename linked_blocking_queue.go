@@ -0,0 +1,13 @@
+package container
+
+// LinkedBlockingQueue is a BlockingQueue backed by LinkedQueue,
+// a capacity less than or equal to 0 means the queue is unbounded
+type LinkedBlockingQueue[E any] struct {
+	blockingQueue[E]
+}
+
+func NewLinkedBlockingQueue[E any](capacity int, es ...E) *LinkedBlockingQueue[E] {
+	bq := &LinkedBlockingQueue[E]{}
+	bq.init(NewLinkedQueue[E](es...), capacity)
+	return bq
+}
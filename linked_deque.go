@@ -0,0 +1,217 @@
+package container
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+type LinkedDeque[E any] struct {
+	UnimplementedLinkedContainer[E]
+	head     LinkedNode[E]
+	len      int
+	snaps    snapshots[E]
+	modCount int
+	rw       sync.RWMutex
+}
+
+func NewLinkedDeque[E any](es ...E) *LinkedDeque[E] {
+	ld := &LinkedDeque[E]{}
+	ld.init()
+	for _, e := range es {
+		ld.insertBefore(&ld.head, e)
+	}
+	return ld
+}
+
+func (ld *LinkedDeque[E]) getNode(i int) *LinkedNode[E] {
+	mi := ld.len / 2
+	if i < mi {
+		node := ld.head.next
+		for j := 0; j < mi; j++ {
+			if j == i {
+				break
+			}
+			node = node.next
+		}
+		return node
+	} else {
+		node := ld.head.prev
+		for j := ld.len - 1; j >= mi; j-- {
+			if j == i {
+				break
+			}
+			node = node.prev
+		}
+		return node
+	}
+}
+
+func (ld *LinkedDeque[E]) init() {
+	ld.snaps.preserve()
+	ld.head.prev = &ld.head
+	ld.head.next = &ld.head
+	ld.len = 0
+	ld.modCount++
+}
+
+// insertBefore links a new node holding e in front of mark
+func (ld *LinkedDeque[E]) insertBefore(mark *LinkedNode[E], e E) {
+	ld.snaps.preserve()
+	node := &LinkedNode[E]{
+		e:    e,
+		prev: mark.prev,
+		next: mark,
+	}
+	mark.prev.next = node
+	mark.prev = node
+	ld.len++
+	ld.modCount++
+}
+
+func (ld *LinkedDeque[E]) removeNode(node *LinkedNode[E]) E {
+	ld.snaps.preserve()
+	e := node.e
+	node.prev.next = node.next
+	node.next.prev = node.prev
+	node = nil
+	ld.len--
+	ld.modCount++
+	return e
+}
+
+func (ld *LinkedDeque[E]) Head() *LinkedNode[E] {
+	return &ld.head
+}
+
+func (ld *LinkedDeque[E]) Clear() {
+	ld.rw.Lock()
+	defer ld.rw.Unlock()
+	ld.init()
+}
+
+func (ld *LinkedDeque[E]) Get(i int) (E, error) {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	var e E
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= ld.len {
+		return e, ErrIndexGteSize
+	}
+	return ld.getNode(i).e, nil
+}
+
+func (ld *LinkedDeque[E]) IsEmpty() bool {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	return ld.head.next == &ld.head
+}
+
+func (ld *LinkedDeque[E]) Iterator() Iterator[E] {
+	return NewLinkedIterator[E](ld)
+}
+
+func (ld *LinkedDeque[E]) linkedState() (*sync.RWMutex, *int) {
+	return &ld.rw, &ld.modCount
+}
+
+func (ld *LinkedDeque[E]) ModCount() int {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	return ld.modCount
+}
+
+func (ld *LinkedDeque[E]) Size() int {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	return ld.len
+}
+
+func (ld *LinkedDeque[E]) SnapshotIterator() Iterator[E] {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	return newLinkedSnapshotIterator[E](&ld.rw, &ld.modCount, &ld.snaps, ld.head.next, ld.len)
+}
+
+func (ld *LinkedDeque[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	return newReverseLinkedSnapshotIterator[E](&ld.rw, &ld.modCount, &ld.snaps, ld.head.prev, ld.len), ld.len
+}
+
+func (ld *LinkedDeque[E]) ToSlice() []E {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	es := make([]E, ld.len)
+	node := ld.head.next
+	for i := 0; i < ld.len; i++ {
+		es[i] = node.e
+		node = node.next
+	}
+	return es
+}
+
+func (ld *LinkedDeque[E]) PushFront(e E) {
+	ld.rw.Lock()
+	defer ld.rw.Unlock()
+	ld.insertBefore(ld.head.next, e)
+}
+
+func (ld *LinkedDeque[E]) PushBack(e E) {
+	ld.rw.Lock()
+	defer ld.rw.Unlock()
+	ld.insertBefore(&ld.head, e)
+}
+
+func (ld *LinkedDeque[E]) PopFront() (E, error) {
+	ld.rw.Lock()
+	defer ld.rw.Unlock()
+	var e E
+	if ld.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	return ld.removeNode(ld.head.next), nil
+}
+
+func (ld *LinkedDeque[E]) PopBack() (E, error) {
+	ld.rw.Lock()
+	defer ld.rw.Unlock()
+	var e E
+	if ld.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	return ld.removeNode(ld.head.prev), nil
+}
+
+func (ld *LinkedDeque[E]) PeekFront() (E, error) {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	var e E
+	if ld.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	return ld.head.next.e, nil
+}
+
+func (ld *LinkedDeque[E]) PeekBack() (E, error) {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	var e E
+	if ld.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	return ld.head.prev.e, nil
+}
+
+func (ld *LinkedDeque[E]) String() string {
+	ld.rw.RLock()
+	defer ld.rw.RUnlock()
+	str := "["
+	for node := ld.head.next; node != &ld.head; node = node.next {
+		str += fmt.Sprintf("%v ", node.e)
+	}
+	str = strings.TrimRight(str, " ") + "]"
+	return str
+}
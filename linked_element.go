@@ -0,0 +1,171 @@
+package container
+
+import "errors"
+
+var ErrElementDetached = errors.New("error: element cannot be used once it has left its list")
+
+// Element is a handle on an element of a LinkedList. Its methods take the lock of the list,
+// and fail once the element has been removed or moved to another list.
+type Element[E any] struct {
+	node *LinkedNode[E]
+	list *LinkedList[E]
+}
+
+// element returns nil for the sentinel
+func (ll *LinkedList[E]) element(node *LinkedNode[E]) *Element[E] {
+	if node == &ll.head {
+		return nil
+	}
+	return &Element[E]{node: node, list: ll}
+}
+
+// Front returns the first element, or nil if the list is empty
+func (ll *LinkedList[E]) Front() *Element[E] {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return ll.element(ll.head.next)
+}
+
+// Back returns the last element, or nil if the list is empty
+func (ll *LinkedList[E]) Back() *Element[E] {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return ll.element(ll.head.prev)
+}
+
+// valid must be called with the lock of the list of e held
+func (e *Element[E]) valid() bool {
+	return e.list.validNode(e.node)
+}
+
+// Next returns the next element, or nil if e is the last one or has left its list
+func (e *Element[E]) Next() *Element[E] {
+	e.list.rw.RLock()
+	defer e.list.rw.RUnlock()
+	if !e.valid() {
+		return nil
+	}
+	return e.list.element(e.node.next)
+}
+
+// Prev returns the previous element, or nil if e is the first one or has left its list
+func (e *Element[E]) Prev() *Element[E] {
+	e.list.rw.RLock()
+	defer e.list.rw.RUnlock()
+	if !e.valid() {
+		return nil
+	}
+	return e.list.element(e.node.prev)
+}
+
+// Value returns the value of e. Once e has been removed it is the last value of e,
+// once e has been moved to another list, whose lock is not held, it is the zero value.
+func (e *Element[E]) Value() E {
+	e.list.rw.RLock()
+	defer e.list.rw.RUnlock()
+	// a removed node is not written anymore, and storing its nil owner publishes its last value
+	if !e.valid() && e.node.owner.Load() != nil {
+		var v E
+		return v
+	}
+	return e.node.e
+}
+
+func (e *Element[E]) SetValue(v E) error {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if !e.valid() {
+		return ErrElementDetached
+	}
+	e.list.snaps.preserve()
+	e.node.e = v
+	return nil
+}
+
+// InsertBefore inserts v in front of e and returns its element
+func (e *Element[E]) InsertBefore(v E) (*Element[E], error) {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if !e.valid() {
+		return nil, ErrElementDetached
+	}
+	return e.list.element(e.list.insertBefore(e.node, v)), nil
+}
+
+// InsertAfter inserts v behind e and returns its element
+func (e *Element[E]) InsertAfter(v E) (*Element[E], error) {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if !e.valid() {
+		return nil, ErrElementDetached
+	}
+	return e.list.element(e.list.insertBefore(e.node.next, v)), nil
+}
+
+// Remove removes e from its list and returns its value
+func (e *Element[E]) Remove() (E, error) {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if !e.valid() {
+		var v E
+		return v, ErrElementDetached
+	}
+	return e.list.removeNode(e.node), nil
+}
+
+// MoveToFront moves e to the front of its list
+func (e *Element[E]) MoveToFront() error {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if !e.valid() {
+		return ErrElementDetached
+	}
+	e.list.moveBefore(e.node, e.list.head.next)
+	return nil
+}
+
+// MoveToBack moves e to the back of its list
+func (e *Element[E]) MoveToBack() error {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if !e.valid() {
+		return ErrElementDetached
+	}
+	e.list.moveBefore(e.node, &e.list.head)
+	return nil
+}
+
+// MoveBefore moves e in front of mark, which must be an element of the same list
+func (e *Element[E]) MoveBefore(mark *Element[E]) error {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if err := e.checkMark(mark); err != nil {
+		return err
+	}
+	e.list.moveBefore(e.node, mark.node)
+	return nil
+}
+
+// MoveAfter moves e behind mark, which must be an element of the same list
+func (e *Element[E]) MoveAfter(mark *Element[E]) error {
+	e.list.rw.Lock()
+	defer e.list.rw.Unlock()
+	if err := e.checkMark(mark); err != nil {
+		return err
+	}
+	if e.node != mark.node {
+		e.list.moveBefore(e.node, mark.node.next)
+	}
+	return nil
+}
+
+// checkMark must be called with the lock of the list of e held
+func (e *Element[E]) checkMark(mark *Element[E]) error {
+	if mark == nil || mark.list != e.list {
+		return ErrInvalidNode
+	}
+	if !e.valid() || !mark.valid() {
+		return ErrElementDetached
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package container
+
+import (
+	"errors"
+	"testing"
+)
+
+// elementAt returns the element at index i of ll
+func elementAt[E any](ll *LinkedList[E], i int) *Element[E] {
+	e := ll.Front()
+	for ; i > 0; i-- {
+		e = e.Next()
+	}
+	return e
+}
+
+func TestElementMoves(t *testing.T) {
+	tests := []struct {
+		name string
+		move func(ll *LinkedList[int]) error
+		want []int
+	}{
+		{"front", func(ll *LinkedList[int]) error {
+			return elementAt(ll, 2).MoveToFront()
+		}, []int{3, 1, 2, 4}},
+		{"back", func(ll *LinkedList[int]) error {
+			return elementAt(ll, 0).MoveToBack()
+		}, []int{2, 3, 4, 1}},
+		{"before", func(ll *LinkedList[int]) error {
+			return elementAt(ll, 3).MoveBefore(elementAt(ll, 1))
+		}, []int{1, 4, 2, 3}},
+		{"after", func(ll *LinkedList[int]) error {
+			return elementAt(ll, 0).MoveAfter(elementAt(ll, 2))
+		}, []int{2, 3, 1, 4}},
+		{"after itself", func(ll *LinkedList[int]) error {
+			e := elementAt(ll, 1)
+			return e.MoveAfter(e)
+		}, []int{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ll := NewLinkedList(1, 2, 3, 4)
+			if err := tt.move(ll); err != nil {
+				t.Fatal(err)
+			}
+			checkRing(t, ll, tt.want)
+		})
+	}
+}
+
+func TestElementMovesRejectInvalidElements(t *testing.T) {
+	a := NewLinkedList(1, 2, 3)
+	b := NewLinkedList(4, 5)
+	removed := a.Back()
+	if _, err := removed.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if err := removed.MoveToFront(); !errors.Is(err, ErrElementDetached) {
+		t.Errorf("MoveToFront of a removed element = %v, want ErrElementDetached", err)
+	}
+	if err := a.Front().MoveBefore(removed); !errors.Is(err, ErrElementDetached) {
+		t.Errorf("MoveBefore a removed element = %v, want ErrElementDetached", err)
+	}
+	if err := a.Front().MoveAfter(b.Front()); !errors.Is(err, ErrInvalidNode) {
+		t.Errorf("MoveAfter an element of another list = %v, want ErrInvalidNode", err)
+	}
+	if err := a.Front().MoveBefore(nil); !errors.Is(err, ErrInvalidNode) {
+		t.Errorf("MoveBefore(nil) = %v, want ErrInvalidNode", err)
+	}
+	moved := b.Front()
+	if err := a.Concat(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := moved.MoveToBack(); !errors.Is(err, ErrElementDetached) {
+		t.Errorf("MoveToBack of an element moved to another list = %v, want ErrElementDetached", err)
+	}
+	checkRing(t, a, []int{1, 2, 4, 5})
+	checkRing(t, b, nil)
+}
+
+func TestElementOfConcatenatedListConcurrently(t *testing.T) {
+	a := NewLinkedList(1, 2, 3)
+	b := NewLinkedList[int]()
+	el := a.Front()
+	if err := b.Concat(a); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			if err := el.SetValue(i); !errors.Is(err, ErrElementDetached) {
+				t.Errorf("SetValue after Concat = %v, want ErrElementDetached", err)
+				return
+			}
+			el.Value()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		b.Add(i)
+		b.RemoveStart()
+	}
+	<-done
+}
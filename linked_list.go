@@ -4,17 +4,36 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
-type LinkedList[E comparable] struct {
+type LinkedList[E any] struct {
 	UnimplementedLinkedContainer[E]
-	head LinkedNode[E]
-	len  int
-	rw   sync.RWMutex
+	head     LinkedNode[E]
+	len      int
+	equality equality[E]
+	snaps    snapshots[E]
+	// home is stored in the nodes of the list, mixed is set once nodes of another list
+	// have been spliced in, whose owner is updated at the next ownership check
+	home     *nodeOwner
+	mixed    atomic.Bool
+	modCount int
+	rw       sync.RWMutex
 }
 
 func NewLinkedList[E comparable](es ...E) *LinkedList[E] {
-	ll := &LinkedList[E]{}
+	return newLinkedList[E](comparableEquality[E](), es)
+}
+
+// NewLinkedListFunc returns a list which compares its elements with equal,
+// so that the elements do not need to be comparable.
+// A nil equal is ==, which panics if the dynamic type of the elements is not comparable.
+func NewLinkedListFunc[E any](equal func(a, b E) bool, es ...E) *LinkedList[E] {
+	return newLinkedList[E](equality[E]{equal: equal}, es)
+}
+
+func newLinkedList[E any](eq equality[E], es []E) *LinkedList[E] {
+	ll := &LinkedList[E]{equality: eq}
 	ll.init()
 	for _, e := range es {
 		ll.add(e)
@@ -22,55 +41,297 @@ func NewLinkedList[E comparable](es ...E) *LinkedList[E] {
 	return ll
 }
 
+func (ll *LinkedList[E]) eq(a, b E) bool {
+	return ll.equality.eq(a, b)
+}
+
 func (ll *LinkedList[E]) add(e E) {
-	node := &LinkedNode[E]{
-		e:    e,
-		prev: ll.head.prev,
-		next: &ll.head,
-	}
-	ll.head.prev.next = node
-	ll.head.prev = node
-	ll.len++
+	ll.insertBefore(&ll.head, e)
 }
 
+// getNode returns the node at index i, or the sentinel if i is the list length
 func (ll *LinkedList[E]) getNode(i int) *LinkedNode[E] {
-	mi := ll.len / 2
-	if i < mi {
+	if i < ll.len/2 {
 		node := ll.head.next
-		for j := 0; j < mi; j++ {
-			if j == i {
-				break
-			}
+		for j := 0; j < i; j++ {
 			node = node.next
 		}
 		return node
-	} else {
-		node := ll.head.prev
-		for j := ll.len - 1; j >= mi; j-- {
-			if j == i {
-				break
+	}
+	node := &ll.head
+	for j := ll.len; j > i; j-- {
+		node = node.prev
+	}
+	return node
+}
+
+// mergeSort sorts the nodes with a bottom-up merge sort which relinks them in place,
+// it is stable and allocates nothing
+func (ll *LinkedList[E]) mergeSort(less func(a, b E) bool) {
+	if ll.len < 2 {
+		return
+	}
+	ll.snaps.preserve()
+	// detach the nodes into a nil-terminated singly linked list
+	list := ll.head.next
+	ll.head.prev.next = nil
+	for k := 1; ; k <<= 1 {
+		var head, tail *LinkedNode[E]
+		p := list
+		merges := 0
+		for p != nil {
+			merges++
+			q := p
+			pSize := 0
+			for ; pSize < k && q != nil; pSize++ {
+				q = q.next
 			}
-			node = node.prev
+			qSize := k
+			for pSize > 0 || (qSize > 0 && q != nil) {
+				var node *LinkedNode[E]
+				if pSize == 0 || (qSize > 0 && q != nil && less(q.e, p.e)) {
+					node = q
+					q = q.next
+					qSize--
+				} else {
+					node = p
+					p = p.next
+					pSize--
+				}
+				if tail == nil {
+					head = node
+				} else {
+					tail.next = node
+				}
+				tail = node
+			}
+			p = q
+		}
+		tail.next = nil
+		list = head
+		if merges <= 1 {
+			break
 		}
-		return node
 	}
+	// restore the prev pointers and close the ring
+	prev := &ll.head
+	for node := list; node != nil; node = node.next {
+		node.prev = prev
+		prev.next = node
+		prev = node
+	}
+	prev.next = &ll.head
+	ll.head.prev = prev
+	ll.modCount++
 }
 
 func (ll *LinkedList[E]) init() {
+	ll.snaps.preserve()
+	if ll.home == nil {
+		ll.home = new(nodeOwner)
+	}
 	ll.head.next = &ll.head
 	ll.head.prev = &ll.head
 	ll.len = 0
+	ll.modCount++
+}
+
+// reset replaces the elements with es, the elements of the previous nodes become invalid
+func (ll *LinkedList[E]) reset(es []E) {
+	if ll.head.next != nil {
+		ll.detach()
+	}
+	ll.init()
+	for _, e := range es {
+		ll.add(e)
+	}
+}
+
+// detach unlinks all the nodes, so that their elements become invalid
+func (ll *LinkedList[E]) detach() {
+	ll.snaps.preserve()
+	for node := ll.head.next; node != &ll.head; {
+		next := node.next
+		node.prev, node.next = nil, nil
+		node.owner.Store(nil)
+		node = next
+	}
+}
+
+// insertBefore links a new node holding e in front of mark
+func (ll *LinkedList[E]) insertBefore(mark *LinkedNode[E], e E) *LinkedNode[E] {
+	ll.snaps.preserve()
+	node := &LinkedNode[E]{
+		e:    e,
+		prev: mark.prev,
+		next: mark,
+	}
+	node.owner.Store(ll.home)
+	mark.prev.next = node
+	mark.prev = node
+	ll.len++
+	ll.modCount++
+	return node
+}
+
+// searchNode returns the first node for which pred is true and its index,
+// or the sentinel and the list length if there is none
+func (ll *LinkedList[E]) searchNode(pred func(E) bool) (int, *LinkedNode[E]) {
+	i := 0
+	node := ll.head.next
+	for ; node != &ll.head; node = node.next {
+		if pred(node.e) {
+			break
+		}
+		i++
+	}
+	return i, node
+}
+
+// search is a linear scan since a linked list cannot be accessed randomly in O(1)
+func (ll *LinkedList[E]) search(pred func(E) bool) (int, E, bool) {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	i, node := ll.searchNode(pred)
+	return i, node.e, node != &ll.head
+}
+
+func (ll *LinkedList[E]) insertSorted(pred func(E) bool, e E) int {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	i, node := ll.searchNode(pred)
+	ll.insertBefore(node, e)
+	return i
 }
 
 func (ll *LinkedList[E]) removeNode(node *LinkedNode[E]) E {
+	ll.snaps.preserve()
 	e := node.e
 	node.prev.next = node.next
 	node.next.prev = node.prev
-	node = nil
+	node.prev, node.next = nil, nil
+	node.owner.Store(nil)
 	ll.len--
+	ll.modCount++
 	return e
 }
 
+func (ll *LinkedList[E]) rwMutex() *sync.RWMutex {
+	return &ll.rw
+}
+
+func (ll *LinkedList[E]) elemEquality() equality[E] {
+	return ll.equality
+}
+
+func (ll *LinkedList[E]) getModCount() int {
+	return ll.modCount
+}
+
+func (ll *LinkedList[E]) size() int {
+	return ll.len
+}
+
+func (ll *LinkedList[E]) getAt(i int) E {
+	return ll.getNode(i).e
+}
+
+func (ll *LinkedList[E]) setAt(i int, e E) error {
+	ll.snaps.preserve()
+	ll.getNode(i).e = e
+	return nil
+}
+
+func (ll *LinkedList[E]) forRange(from, to int, fn func(i int, e E) bool) {
+	node := ll.getNode(from)
+	for i := from; i < to; i++ {
+		if !fn(i, node.e) {
+			return
+		}
+		node = node.next
+	}
+}
+
+func (ll *LinkedList[E]) setRange(from int, es []E) error {
+	ll.snaps.preserve()
+	node := ll.getNode(from)
+	for _, e := range es {
+		node.e = e
+		node = node.next
+	}
+	return nil
+}
+
+func (ll *LinkedList[E]) insertAt(i int, e E) error {
+	ll.insertBefore(ll.getNode(i), e)
+	return nil
+}
+
+func (ll *LinkedList[E]) insertAll(i int, es []E) error {
+	node := ll.getNode(i)
+	for _, e := range es {
+		ll.insertBefore(node, e)
+	}
+	return nil
+}
+
+func (ll *LinkedList[E]) removeAt(i int) E {
+	return ll.removeNode(ll.getNode(i))
+}
+
+func (ll *LinkedList[E]) removeRange(from, to int) {
+	node := ll.getNode(from)
+	for i := from; i < to; i++ {
+		next := node.next
+		ll.removeNode(node)
+		node = next
+	}
+}
+
+// replaceRange sets the values of the nodes in [from, to) before it inserts or removes nodes
+func (ll *LinkedList[E]) replaceRange(from, to int, es []E) error {
+	ll.snaps.preserve()
+	node := ll.getNode(from)
+	i := 0
+	for ; i < len(es) && from+i < to; i++ {
+		node.e = es[i]
+		node = node.next
+	}
+	for _, e := range es[i:] {
+		ll.insertBefore(node, e)
+	}
+	for j := from + i; j < to; j++ {
+		next := node.next
+		ll.removeNode(node)
+		node = next
+	}
+	return nil
+}
+
+func (ll *LinkedList[E]) elems() []E {
+	es := make([]E, ll.len)
+	node := ll.head.next
+	for i := 0; i < ll.len; i++ {
+		es[i] = node.e
+		node = node.next
+	}
+	return es
+}
+
+func (ll *LinkedList[E]) removeIf(from, to int, pred func(E) bool) int {
+	n := 0
+	node := ll.getNode(from)
+	for i := from; i < to; i++ {
+		next := node.next
+		if pred(node.e) {
+			ll.removeNode(node)
+			n++
+		}
+		node = next
+	}
+	return n
+}
+
 func (ll *LinkedList[E]) Head() *LinkedNode[E] {
 	ll.rw.RLock()
 	defer ll.rw.RUnlock()
@@ -80,6 +341,7 @@ func (ll *LinkedList[E]) Head() *LinkedNode[E] {
 func (ll *LinkedList[E]) Clear() {
 	ll.rw.Lock()
 	defer ll.rw.Unlock()
+	ll.detach()
 	ll.init()
 }
 
@@ -103,9 +365,17 @@ func (ll *LinkedList[E]) IsEmpty() bool {
 }
 
 func (ll *LinkedList[E]) Iterator() Iterator[E] {
+	return NewLinkedIterator[E](ll)
+}
+
+func (ll *LinkedList[E]) linkedState() (*sync.RWMutex, *int) {
+	return &ll.rw, &ll.modCount
+}
+
+func (ll *LinkedList[E]) ModCount() int {
 	ll.rw.RLock()
 	defer ll.rw.RUnlock()
-	return NewLinkedIterator[E](ll)
+	return ll.modCount
 }
 
 func (ll *LinkedList[E]) Size() int {
@@ -114,16 +384,22 @@ func (ll *LinkedList[E]) Size() int {
 	return ll.len
 }
 
+func (ll *LinkedList[E]) SnapshotIterator() Iterator[E] {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return newLinkedSnapshotIterator[E](&ll.rw, &ll.modCount, &ll.snaps, ll.head.next, ll.len)
+}
+
+func (ll *LinkedList[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return newReverseLinkedSnapshotIterator[E](&ll.rw, &ll.modCount, &ll.snaps, ll.head.prev, ll.len), ll.len
+}
+
 func (ll *LinkedList[E]) ToSlice() []E {
 	ll.rw.RLock()
 	defer ll.rw.RUnlock()
-	es := make([]E, ll.len)
-	node := ll.head.next
-	for i := 0; i < ll.len; i++ {
-		es[i] = node.e
-		node = node.next
-	}
-	return es
+	return ll.elems()
 }
 
 func (ll *LinkedList[E]) Add(e E) {
@@ -132,6 +408,14 @@ func (ll *LinkedList[E]) Add(e E) {
 	ll.add(e)
 }
 
+func (ll *LinkedList[E]) AddAll(es ...E) {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	for _, e := range es {
+		ll.insertBefore(&ll.head, e)
+	}
+}
+
 func (ll *LinkedList[E]) AddToIndex(i int, e E) error {
 	ll.rw.Lock()
 	defer ll.rw.Unlock()
@@ -141,15 +425,7 @@ func (ll *LinkedList[E]) AddToIndex(i int, e E) error {
 	if i > ll.len {
 		return ErrIndexGtSize
 	}
-	node := ll.getNode(i)
-	n := &LinkedNode[E]{
-		e:    e,
-		prev: node.prev,
-		next: node,
-	}
-	node.prev.next = n
-	node.prev = n
-	ll.len++
+	ll.insertBefore(ll.getNode(i), e)
 	return nil
 }
 
@@ -157,18 +433,11 @@ func (ll *LinkedList[E]) AddList(l List[E]) error {
 	if l == ll {
 		return ErrSelf
 	}
+	es := l.ToSlice()
 	ll.rw.Lock()
 	defer ll.rw.Unlock()
-	it := l.Iterator()
-	for it.HasNext() {
-		node := &LinkedNode[E]{
-			e:    it.Next(),
-			prev: ll.head.prev,
-			next: &ll.head,
-		}
-		ll.head.prev.next = node
-		ll.head.prev = node
-		ll.len++
+	for _, e := range es {
+		ll.insertBefore(&ll.head, e)
 	}
 	return nil
 }
@@ -177,6 +446,7 @@ func (ll *LinkedList[E]) AddListToIndex(i int, l List[E]) error {
 	if l == ll {
 		return ErrSelf
 	}
+	es := l.ToSlice()
 	ll.rw.Lock()
 	defer ll.rw.Unlock()
 	if i < 0 {
@@ -185,25 +455,24 @@ func (ll *LinkedList[E]) AddListToIndex(i int, l List[E]) error {
 	if i > ll.len {
 		return ErrIndexGtSize
 	}
-	node := ll.getNode(i)
-	it := l.Iterator()
-	for it.HasNext() {
-		n := &LinkedNode[E]{
-			e:    it.Next(),
-			prev: node.prev,
-			next: node,
-		}
-		node.prev.next = n
-		node.prev = n
-		ll.len++
-	}
-	return nil
+	return ll.insertAll(i, es)
+}
+
+func (ll *LinkedList[E]) Contains(e E) bool {
+	return ll.IndexOf(e) != NotFound
+}
+
+func (ll *LinkedList[E]) ContainsAll(c Container[E]) bool {
+	es := c.ToSlice()
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return containsAll(ll.elems(), es, ll.equality)
 }
 
 func (ll *LinkedList[E]) Copy() List[E] {
 	ll.rw.RLock()
 	defer ll.rw.RUnlock()
-	list := &LinkedList[E]{}
+	list := &LinkedList[E]{equality: ll.equality, home: new(nodeOwner)}
 	list.len = ll.len
 	lNode := &list.head
 	llNode := ll.head.next
@@ -212,6 +481,7 @@ func (ll *LinkedList[E]) Copy() List[E] {
 			e:    llNode.e,
 			prev: lNode,
 		}
+		lNode.next.owner.Store(list.home)
 		lNode = lNode.next
 		llNode = llNode.next
 	}
@@ -225,7 +495,7 @@ func (ll *LinkedList[E]) IndexOf(e E) int {
 	defer ll.rw.RUnlock()
 	node := ll.head.next
 	for i := 0; i < ll.len; i++ {
-		if e == node.e {
+		if ll.eq(node.e, e) {
 			return i
 		}
 		node = node.next
@@ -237,8 +507,8 @@ func (ll *LinkedList[E]) LastIndexOf(e E) int {
 	ll.rw.RLock()
 	defer ll.rw.RUnlock()
 	node := ll.head.prev
-	for i := ll.len; i >= 0; i-- {
-		if e == node.e {
+	for i := ll.len - 1; i >= 0; i-- {
+		if ll.eq(node.e, e) {
 			return i
 		}
 		node = node.prev
@@ -246,6 +516,24 @@ func (ll *LinkedList[E]) LastIndexOf(e E) int {
 	return NotFound
 }
 
+func (ll *LinkedList[E]) InsertSlice(i int, es []E) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	if i < 0 {
+		return ErrIndexLtZero
+	}
+	if i > ll.len {
+		return ErrIndexGtSize
+	}
+	return ll.insertAll(i, es)
+}
+
+func (ll *LinkedList[E]) ListIterator() ListIterator[E] {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return newLinkedListIterator[E](ll)
+}
+
 func (ll *LinkedList[E]) RemoveElements(e E) bool {
 	ll.rw.Lock()
 	defer ll.rw.Unlock()
@@ -253,7 +541,7 @@ func (ll *LinkedList[E]) RemoveElements(e E) bool {
 	node := ll.head.next
 	for node != &ll.head {
 		next := node.next
-		if e == node.e {
+		if ll.eq(node.e, e) {
 			ll.removeNode(node)
 			success = true
 		}
@@ -298,6 +586,48 @@ func (ll *LinkedList[E]) RemoveByIndex(i int) (E, error) {
 	return ll.removeNode(node), nil
 }
 
+func (ll *LinkedList[E]) RemoveAll(c Container[E]) bool {
+	es := c.ToSlice()
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	return ll.removeIf(0, ll.len, ll.equality.matcher(es)) > 0
+}
+
+func (ll *LinkedList[E]) RemoveRange(from, to int) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	if err := checkRange(from, to, ll.len); err != nil {
+		return err
+	}
+	ll.removeRange(from, to)
+	return nil
+}
+
+func (ll *LinkedList[E]) ReplaceRange(from, to int, es []E) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	if err := checkRange(from, to, ll.len); err != nil {
+		return err
+	}
+	return ll.replaceRange(from, to, es)
+}
+
+func (ll *LinkedList[E]) RemoveIf(pred func(E) bool) bool {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	return ll.removeIf(0, ll.len, pred) > 0
+}
+
+func (ll *LinkedList[E]) RetainAll(c Container[E]) bool {
+	es := c.ToSlice()
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	contains := ll.equality.matcher(es)
+	return ll.removeIf(0, ll.len, func(e E) bool {
+		return !contains(e)
+	}) > 0
+}
+
 func (ll *LinkedList[E]) Set(i int, e E) error {
 	ll.rw.Lock()
 	defer ll.rw.Unlock()
@@ -311,10 +641,32 @@ func (ll *LinkedList[E]) Set(i int, e E) error {
 	for ii := 0; ii <= i; ii++ {
 		node = node.next
 	}
+	ll.snaps.preserve()
 	node.e = e
 	return nil
 }
 
+// Sort sorts the list in the order determined by less, it is the same as SortStable
+func (ll *LinkedList[E]) Sort(less func(a, b E) bool) {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	ll.mergeSort(less)
+}
+
+// SortStable sorts the list in the order determined by less and keeps the original order of equal elements
+func (ll *LinkedList[E]) SortStable(less func(a, b E) bool) {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	ll.mergeSort(less)
+}
+
+// SubList returns a view of the elements in [from, to), see List.SubList
+func (ll *LinkedList[E]) SubList(from, to int) (View[E], error) {
+	ll.rw.RLock()
+	defer ll.rw.RUnlock()
+	return newSubList[E](ll, from, to)
+}
+
 func (ll *LinkedList[E]) String() string {
 	ll.rw.RLock()
 	defer ll.rw.RUnlock()
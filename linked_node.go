@@ -1,16 +1,28 @@
 package container
 
+import "sync/atomic"
+
 // LinkedNode 此结构体定义双向链表节点结构
 // 链表，链式栈，链式队列的节点皆可使用此结构
-type LinkedNode[E comparable] struct {
+type LinkedNode[E any] struct {
 	e          E
 	prev, next *LinkedNode[E]
+	// owner identifies the LinkedList holding the node, see LinkedList.validNode.
+	// It is nil for the sentinel, the removed nodes and the nodes of the other containers.
+	owner atomic.Pointer[nodeOwner]
+}
+
+// nodeOwner is the identity of a LinkedList stored in its nodes,
+// it is not empty so that every nodeOwner has its own address
+type nodeOwner struct {
+	_ byte
 }
 
 func (node *LinkedNode[E]) Value() E {
 	return node.e
 }
 
+// Deprecated: SetValue bypasses the lock of the list, use Element.SetValue instead.
 func (node *LinkedNode[E]) SetValue(e E) {
 	node.e = e
 }
@@ -19,6 +31,7 @@ func (node *LinkedNode[E]) Prev() *LinkedNode[E] {
 	return node.prev
 }
 
+// Deprecated: SetPrev can corrupt the list, use the methods of LinkedList and Element instead.
 func (node *LinkedNode[E]) SetPrev(prev *LinkedNode[E]) {
 	node.prev = prev
 }
@@ -27,6 +40,7 @@ func (node *LinkedNode[E]) Next() *LinkedNode[E] {
 	return node.next
 }
 
+// Deprecated: SetNext can corrupt the list, use the methods of LinkedList and Element instead.
 func (node *LinkedNode[E]) SetNext(next *LinkedNode[E]) {
 	node.next = next
 }
@@ -6,14 +6,16 @@ import (
 	"sync"
 )
 
-type LinkedQueue[E comparable] struct {
+type LinkedQueue[E any] struct {
 	UnimplementedLinkedContainer[E]
-	head LinkedNode[E]
-	len  int
-	rw   sync.RWMutex
+	head     LinkedNode[E]
+	len      int
+	snaps    snapshots[E]
+	modCount int
+	rw       sync.RWMutex
 }
 
-func NewLinkedQueue[E comparable](es ...E) *LinkedQueue[E] {
+func NewLinkedQueue[E any](es ...E) *LinkedQueue[E] {
 	ls := &LinkedQueue[E]{}
 	ls.init()
 	for _, e := range es {
@@ -46,12 +48,15 @@ func (lq *LinkedQueue[E]) getNode(i int) *LinkedNode[E] {
 }
 
 func (lq *LinkedQueue[E]) init() {
+	lq.snaps.preserve()
 	lq.head.prev = &lq.head
 	lq.head.next = &lq.head
 	lq.len = 0
+	lq.modCount++
 }
 
 func (lq *LinkedQueue[E]) en(e E) {
+	lq.snaps.preserve()
 	node := &LinkedNode[E]{
 		e:    e,
 		prev: lq.head.prev,
@@ -60,6 +65,15 @@ func (lq *LinkedQueue[E]) en(e E) {
 	lq.head.prev.next = node
 	lq.head.prev = node
 	lq.len++
+	lq.modCount++
+}
+
+// reset replaces the elements with es
+func (lq *LinkedQueue[E]) reset(es []E) {
+	lq.init()
+	for _, e := range es {
+		lq.en(e)
+	}
 }
 
 func (lq *LinkedQueue[E]) Head() *LinkedNode[E] {
@@ -93,9 +107,17 @@ func (lq *LinkedQueue[E]) IsEmpty() bool {
 }
 
 func (lq *LinkedQueue[E]) Iterator() Iterator[E] {
+	return NewLinkedIterator[E](lq)
+}
+
+func (lq *LinkedQueue[E]) linkedState() (*sync.RWMutex, *int) {
+	return &lq.rw, &lq.modCount
+}
+
+func (lq *LinkedQueue[E]) ModCount() int {
 	lq.rw.RLock()
 	defer lq.rw.RUnlock()
-	return NewLinkedIterator[E](lq)
+	return lq.modCount
 }
 
 func (lq *LinkedQueue[E]) Size() int {
@@ -104,6 +126,18 @@ func (lq *LinkedQueue[E]) Size() int {
 	return lq.len
 }
 
+func (lq *LinkedQueue[E]) SnapshotIterator() Iterator[E] {
+	lq.rw.RLock()
+	defer lq.rw.RUnlock()
+	return newLinkedSnapshotIterator[E](&lq.rw, &lq.modCount, &lq.snaps, lq.head.next, lq.len)
+}
+
+func (lq *LinkedQueue[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	lq.rw.RLock()
+	defer lq.rw.RUnlock()
+	return newReverseLinkedSnapshotIterator[E](&lq.rw, &lq.modCount, &lq.snaps, lq.head.prev, lq.len), lq.len
+}
+
 func (lq *LinkedQueue[E]) ToSlice() []E {
 	lq.rw.RLock()
 	defer lq.rw.RUnlock()
@@ -128,12 +162,15 @@ func (lq *LinkedQueue[E]) De() (E, error) {
 	var e E
 	front := lq.head.next
 	if front == &lq.head {
-		return e, ErrStackEmpty
+		return e, ErrQueueEmpty
 	}
+	lq.snaps.preserve()
 	e = front.e
 	front.next.prev = &lq.head
 	lq.head.next = front.next
 	front = nil
+	lq.len--
+	lq.modCount++
 	return e, nil
 }
 
@@ -0,0 +1,197 @@
+package container
+
+import (
+	"errors"
+	"unsafe"
+)
+
+var (
+	ErrInvalidNode   = errors.New("error: node cannot be outside the list")
+	ErrSpliceOverlap = errors.New("error: param at cannot be inside the spliced range")
+)
+
+// lockPair locks a and b in the order of their addresses, so that two goroutines
+// locking the same lists in opposite roles cannot deadlock
+func lockPair[E any](a, b *LinkedList[E]) (unlock func()) {
+	if a == b {
+		a.rw.Lock()
+		return a.rw.Unlock
+	}
+	if uintptr(unsafe.Pointer(b)) < uintptr(unsafe.Pointer(a)) {
+		a, b = b, a
+	}
+	a.rw.Lock()
+	b.rw.Lock()
+	return func() {
+		b.rw.Unlock()
+		a.rw.Unlock()
+	}
+}
+
+// unlinkRun removes the n nodes from first to last from the ring without touching them
+func (ll *LinkedList[E]) unlinkRun(first, last *LinkedNode[E], n int) {
+	ll.snaps.preserve()
+	first.prev.next = last.next
+	last.next.prev = first.prev
+	ll.len -= n
+	ll.modCount++
+}
+
+// linkRun links the n nodes from first to last in front of mark without touching the others
+func (ll *LinkedList[E]) linkRun(first, last, mark *LinkedNode[E], n int) {
+	ll.snaps.preserve()
+	first.prev = mark.prev
+	last.next = mark
+	mark.prev.next = first
+	mark.prev = last
+	ll.len += n
+	ll.modCount++
+}
+
+// handOver records in O(1) that nodes of src have been moved to dst, both locked.
+// src takes a new owner, so that the moved nodes are no longer its own,
+// and the nodes left in src and the nodes now in dst get the owner of their list
+// at the next ownership check of that list.
+func handOver[E any](dst, src *LinkedList[E]) {
+	src.home = new(nodeOwner)
+	src.mixed.Store(src.len > 0)
+	dst.mixed.Store(true)
+}
+
+// validNode reports whether node is one of the nodes of the list,
+// which rules out the sentinel, the removed nodes and the nodes of any other list.
+// It must be called with the lock held, the read lock is enough.
+func (ll *LinkedList[E]) validNode(node *LinkedNode[E]) bool {
+	if node == nil {
+		return false
+	}
+	switch node.owner.Load() {
+	case nil:
+		return false
+	case ll.home:
+		return true
+	}
+	if ll.mixed.Load() {
+		ll.relabel()
+	}
+	return node.owner.Load() == ll.home
+}
+
+// relabel stores the owner of the list in all its nodes after nodes have been spliced in,
+// the concurrent readers store the same owner
+func (ll *LinkedList[E]) relabel() {
+	for node := ll.head.next; node != &ll.head; node = node.next {
+		node.owner.Store(ll.home)
+	}
+	ll.mixed.Store(false)
+}
+
+// moveBefore moves node in front of mark
+func (ll *LinkedList[E]) moveBefore(node, mark *LinkedNode[E]) {
+	if node == mark || node.next == mark {
+		return
+	}
+	ll.unlinkRun(node, node, 1)
+	ll.linkRun(node, node, mark, 1)
+}
+
+// Splice moves the nodes in [from, to) of src in front of the node at index at of dst.
+// The nodes are relinked rather than copied, so the cost does not depend on to - from
+// once the nodes at the ends of the range have been located. Between two lists,
+// the first ownership check of Element or of the Move methods on either list then visits
+// its nodes once, to record their list.
+// dst and src may be the same list, then at refers to the index before the move.
+func Splice[E any](dst *LinkedList[E], at int, src *LinkedList[E], from, to int) error {
+	unlock := lockPair(dst, src)
+	defer unlock()
+	if at < 0 {
+		return ErrIndexLtZero
+	}
+	if at > dst.len {
+		return ErrIndexGtSize
+	}
+	if err := checkRange(from, to, src.len); err != nil {
+		return err
+	}
+	if dst == src && at > from && at < to {
+		return ErrSpliceOverlap
+	}
+	n := to - from
+	if n == 0 || dst == src && (at == from || at == to) {
+		return nil
+	}
+	mark := dst.getNode(at)
+	first, last := src.getNode(from), src.getNode(to-1)
+	src.unlinkRun(first, last, n)
+	dst.linkRun(first, last, mark, n)
+	if dst != src {
+		handOver(dst, src)
+	}
+	return nil
+}
+
+// Concat moves all the nodes of src to the back of ll in O(1), leaving src empty.
+// The nodes are relinked rather than copied, the first ownership check
+// of Element or of the Move methods on ll then visits its nodes once, to record their list.
+func (ll *LinkedList[E]) Concat(src *LinkedList[E]) error {
+	if src == ll {
+		return ErrSelf
+	}
+	unlock := lockPair(ll, src)
+	defer unlock()
+	if src.len == 0 {
+		return nil
+	}
+	n := src.len
+	first, last := src.head.next, src.head.prev
+	src.unlinkRun(first, last, n)
+	ll.linkRun(first, last, &ll.head, n)
+	handOver(ll, src)
+	return nil
+}
+
+// MoveToFront moves node to the front of the list, Element.MoveToFront is the handle based equivalent
+func (ll *LinkedList[E]) MoveToFront(node *LinkedNode[E]) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	if !ll.validNode(node) {
+		return ErrInvalidNode
+	}
+	ll.moveBefore(node, ll.head.next)
+	return nil
+}
+
+// MoveToBack moves node to the back of the list, Element.MoveToBack is the handle based equivalent
+func (ll *LinkedList[E]) MoveToBack(node *LinkedNode[E]) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	if !ll.validNode(node) {
+		return ErrInvalidNode
+	}
+	ll.moveBefore(node, &ll.head)
+	return nil
+}
+
+// MoveBefore moves node in front of mark
+func (ll *LinkedList[E]) MoveBefore(node, mark *LinkedNode[E]) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	if !ll.validNode(node) || !ll.validNode(mark) {
+		return ErrInvalidNode
+	}
+	ll.moveBefore(node, mark)
+	return nil
+}
+
+// MoveAfter moves node behind mark
+func (ll *LinkedList[E]) MoveAfter(node, mark *LinkedNode[E]) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	if !ll.validNode(node) || !ll.validNode(mark) {
+		return ErrInvalidNode
+	}
+	if node != mark {
+		ll.moveBefore(node, mark.next)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package container
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// checkRing fails if the links of ll are inconsistent or do not hold want
+func checkRing[E any](t *testing.T, ll *LinkedList[E], want []E) {
+	t.Helper()
+	var got []E
+	n := 0
+	for node := ll.head.next; node != &ll.head; node = node.next {
+		if node.next.prev != node || !ll.validNode(node) {
+			t.Fatalf("broken link at index %d", n)
+		}
+		got = append(got, node.e)
+		n++
+	}
+	if n != ll.len {
+		t.Fatalf("len = %d, the ring holds %d nodes", ll.len, n)
+	}
+	if len(want) == 0 && len(got) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("elements = %v, want %v", got, want)
+	}
+}
+
+func TestMoveRejectsForeignNodes(t *testing.T) {
+	a := NewLinkedList(1, 2, 3)
+	b := NewLinkedList(4, 5, 6)
+	foreign := b.head.next
+	removed := a.head.prev
+	a.removeNode(removed)
+	for name, node := range map[string]*LinkedNode[int]{
+		"foreign":  foreign,
+		"removed":  removed,
+		"sentinel": &a.head,
+		"nil":      nil,
+	} {
+		if err := a.MoveToFront(node); !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("MoveToFront(%s) = %v, want ErrInvalidNode", name, err)
+		}
+		if err := a.MoveToBack(node); !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("MoveToBack(%s) = %v, want ErrInvalidNode", name, err)
+		}
+		if err := a.MoveBefore(node, a.head.next); !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("MoveBefore(%s, front) = %v, want ErrInvalidNode", name, err)
+		}
+		if err := a.MoveAfter(a.head.next, node); !errors.Is(err, ErrInvalidNode) {
+			t.Errorf("MoveAfter(front, %s) = %v, want ErrInvalidNode", name, err)
+		}
+	}
+	checkRing(t, a, []int{1, 2})
+	checkRing(t, b, []int{4, 5, 6})
+}
+
+func TestMoveAcceptsNodesAfterSplice(t *testing.T) {
+	a := NewLinkedList(1, 2, 3)
+	b := NewLinkedList(4, 5, 6)
+	moved := b.head.next
+	if err := Splice(a, 0, b, 0, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.MoveToBack(moved); !errors.Is(err, ErrInvalidNode) {
+		t.Fatalf("MoveToBack on the old list = %v, want ErrInvalidNode", err)
+	}
+	if err := a.MoveToBack(moved); err != nil {
+		t.Fatal(err)
+	}
+	checkRing(t, a, []int{5, 1, 2, 3, 4})
+	checkRing(t, b, []int{6})
+}
+
+func TestSplice(t *testing.T) {
+	tests := []struct {
+		name           string
+		at, from, to   int
+		same           bool
+		wantDst, wantS []int
+	}{
+		{"front", 0, 1, 3, false, []int{5, 6, 1, 2, 3}, []int{4, 7}},
+		{"back", 3, 0, 4, false, []int{1, 2, 3, 4, 5, 6, 7}, nil},
+		{"empty range", 1, 2, 2, false, []int{1, 2, 3}, []int{4, 5, 6, 7}},
+		{"same list forward", 3, 0, 1, true, []int{2, 3, 1}, nil},
+		{"same list backward", 0, 2, 3, true, []int{3, 1, 2}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := NewLinkedList(1, 2, 3)
+			src := NewLinkedList(4, 5, 6, 7)
+			if tt.same {
+				src = dst
+			}
+			if err := Splice(dst, tt.at, src, tt.from, tt.to); err != nil {
+				t.Fatal(err)
+			}
+			checkRing(t, dst, tt.wantDst)
+			if !tt.same {
+				checkRing(t, src, tt.wantS)
+			}
+		})
+	}
+}
+
+func TestSpliceErrors(t *testing.T) {
+	ll := NewLinkedList(1, 2, 3, 4)
+	if err := Splice(ll, 2, ll, 1, 3); !errors.Is(err, ErrSpliceOverlap) {
+		t.Errorf("overlap = %v, want ErrSpliceOverlap", err)
+	}
+	if err := Splice(ll, 5, ll, 0, 1); !errors.Is(err, ErrIndexGtSize) {
+		t.Errorf("at > size = %v, want ErrIndexGtSize", err)
+	}
+	if err := Splice(ll, 0, ll, 3, 2); !errors.Is(err, ErrFromGtTo) {
+		t.Errorf("from > to = %v, want ErrFromGtTo", err)
+	}
+	checkRing(t, ll, []int{1, 2, 3, 4})
+}
+
+func TestConcat(t *testing.T) {
+	a := NewLinkedList(1, 2)
+	b := NewLinkedList(3, 4)
+	if err := a.Concat(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.Concat(a); !errors.Is(err, ErrSelf) {
+		t.Fatalf("Concat(self) = %v, want ErrSelf", err)
+	}
+	checkRing(t, a, []int{1, 2, 3, 4})
+	checkRing(t, b, nil)
+	b.Add(5)
+	checkRing(t, b, []int{5})
+}
+
+func TestConcatDoesNotVisitTheNodes(t *testing.T) {
+	a := NewLinkedList(1, 2)
+	b := NewLinkedList(3, 4)
+	old := b.home
+	if err := a.Concat(b); err != nil {
+		t.Fatal(err)
+	}
+	for node := a.head.next.next.next; node != &a.head; node = node.next {
+		if node.owner.Load() != old {
+			t.Fatal("Concat visited the moved nodes")
+		}
+	}
+	if !a.validNode(a.head.prev) {
+		t.Fatal("a moved node is not valid in its new list")
+	}
+	if a.mixed.Load() {
+		t.Fatal("the list is still marked after its nodes were relabelled")
+	}
+}
+
+func TestSpliceOwnership(t *testing.T) {
+	a := NewLinkedList(1, 2, 3)
+	b := NewLinkedList(4, 5, 6, 7)
+	kept, moved := elementAt(b, 0), elementAt(b, 1)
+	if err := Splice(a, 3, b, 1, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := kept.SetValue(40); err != nil {
+		t.Fatalf("SetValue of an element left in the list = %v", err)
+	}
+	if err := moved.SetValue(50); !errors.Is(err, ErrElementDetached) {
+		t.Fatalf("SetValue of an element moved to another list = %v, want ErrElementDetached", err)
+	}
+	if err := elementAt(a, 3).SetValue(51); err != nil {
+		t.Fatalf("SetValue of a moved element through its new list = %v", err)
+	}
+	// the handle is valid again once its node is back in its list
+	if err := Splice(b, 1, a, 3, 5); err != nil {
+		t.Fatal(err)
+	}
+	if err := moved.SetValue(50); err != nil {
+		t.Fatalf("SetValue of an element moved back = %v", err)
+	}
+	checkRing(t, a, []int{1, 2, 3})
+	checkRing(t, b, []int{40, 50, 6, 7})
+}
@@ -6,14 +6,16 @@ import (
 	"sync"
 )
 
-type LinkedStack[E comparable] struct {
+type LinkedStack[E any] struct {
 	UnimplementedLinkedContainer[E]
-	head LinkedNode[E]
-	len  int
-	rw   sync.RWMutex
+	head     LinkedNode[E]
+	len      int
+	snaps    snapshots[E]
+	modCount int
+	rw       sync.RWMutex
 }
 
-func NewLinkedStack[E comparable](es ...E) *LinkedStack[E] {
+func NewLinkedStack[E any](es ...E) *LinkedStack[E] {
 	ls := &LinkedStack[E]{}
 	ls.init()
 	for _, e := range es {
@@ -46,12 +48,15 @@ func (ls *LinkedStack[E]) getNode(i int) *LinkedNode[E] {
 }
 
 func (ls *LinkedStack[E]) init() {
+	ls.snaps.preserve()
 	ls.head.prev = &ls.head
 	ls.head.next = &ls.head
 	ls.len = 0
+	ls.modCount++
 }
 
 func (ls *LinkedStack[E]) push(e E) {
+	ls.snaps.preserve()
 	node := &LinkedNode[E]{
 		e:    e,
 		prev: ls.head.prev,
@@ -60,6 +65,33 @@ func (ls *LinkedStack[E]) push(e E) {
 	ls.head.prev.next = node
 	ls.head.prev = node
 	ls.len++
+	ls.modCount++
+}
+
+// reset replaces the elements with es, the last one ends up on the top
+func (ls *LinkedStack[E]) reset(es []E) {
+	ls.init()
+	for _, e := range es {
+		ls.push(e)
+	}
+}
+
+func (ls *LinkedStack[E]) removeBottom() (E, error) {
+	ls.rw.Lock()
+	defer ls.rw.Unlock()
+	var e E
+	bottom := ls.head.next
+	if bottom == &ls.head {
+		return e, ErrStackEmpty
+	}
+	ls.snaps.preserve()
+	e = bottom.e
+	bottom.next.prev = &ls.head
+	ls.head.next = bottom.next
+	bottom = nil
+	ls.len--
+	ls.modCount++
+	return e, nil
 }
 
 func (ls *LinkedStack[E]) Head() *LinkedNode[E] {
@@ -93,9 +125,17 @@ func (ls *LinkedStack[E]) IsEmpty() bool {
 }
 
 func (ls *LinkedStack[E]) Iterator() Iterator[E] {
+	return NewLinkedIterator[E](ls)
+}
+
+func (ls *LinkedStack[E]) linkedState() (*sync.RWMutex, *int) {
+	return &ls.rw, &ls.modCount
+}
+
+func (ls *LinkedStack[E]) ModCount() int {
 	ls.rw.RLock()
 	defer ls.rw.RUnlock()
-	return NewLinkedIterator[E](ls)
+	return ls.modCount
 }
 
 func (ls *LinkedStack[E]) Size() int {
@@ -104,6 +144,18 @@ func (ls *LinkedStack[E]) Size() int {
 	return ls.len
 }
 
+func (ls *LinkedStack[E]) SnapshotIterator() Iterator[E] {
+	ls.rw.RLock()
+	defer ls.rw.RUnlock()
+	return newLinkedSnapshotIterator[E](&ls.rw, &ls.modCount, &ls.snaps, ls.head.next, ls.len)
+}
+
+func (ls *LinkedStack[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	ls.rw.RLock()
+	defer ls.rw.RUnlock()
+	return newReverseLinkedSnapshotIterator[E](&ls.rw, &ls.modCount, &ls.snaps, ls.head.prev, ls.len), ls.len
+}
+
 func (ls *LinkedStack[E]) ToSlice() []E {
 	ls.rw.RLock()
 	defer ls.rw.RUnlock()
@@ -135,10 +187,13 @@ func (ls *LinkedStack[E]) Pop() (E, error) {
 	if top == &ls.head {
 		return e, ErrStackEmpty
 	}
+	ls.snaps.preserve()
 	e = top.e
 	top.prev.next = &ls.head
 	ls.head.prev = top.prev
 	top = nil
+	ls.len--
+	ls.modCount++
 	return e, nil
 }
 
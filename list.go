@@ -4,25 +4,132 @@ import (
 	"errors"
 )
 
-type List[E comparable] interface {
+type List[E any] interface {
 	Container[E]
 	Add(e E)
+	AddAll(es ...E)
 	AddToIndex(i int, e E) error
 	AddList(l List[E]) error
 	AddListToIndex(i int, l List[E]) error
+	Contains(e E) bool
+	ContainsAll(c Container[E]) bool
 	Copy() List[E]
 	IndexOf(e E) int
+	InsertSlice(i int, es []E) error
 	LastIndexOf(e E) int
+	ListIterator() ListIterator[E]
 	RemoveElements(e E) bool
 	RemoveStart() (E, error)
 	RemoveLast() (E, error)
 	RemoveByIndex(i int) (E, error)
+	// RemoveAll removes the elements which are in c
+	RemoveAll(c Container[E]) bool
+	// RemoveRange removes the elements in [from, to)
+	RemoveRange(from, to int) error
+	// ReplaceRange replaces the elements in [from, to) with es
+	ReplaceRange(from, to int, es []E) error
+	// RemoveIf removes the elements for which pred is true
+	RemoveIf(pred func(E) bool) bool
+	// RetainAll removes the elements which are not in c
+	RetainAll(c Container[E]) bool
 	Set(i int, e E) error
+	Sort(less func(a, b E) bool)
+	SortStable(less func(a, b E) bool)
+	// SubList returns a view of the elements in [from, to) backed by the list,
+	// changes made through the view are written to the list.
+	// Once the list has been structurally modified other than through the view,
+	// the methods of the view which return an error return ErrConcurrentModification,
+	// and the others act as if the view were empty and record it for Err.
+	SubList(from, to int) (View[E], error)
+}
+
+// View is a list backed by another list, see List.SubList.
+// Err returns the first error met by the methods of the view which cannot return one.
+type View[E any] interface {
+	List[E]
+	Err() error
 }
 
 const NotFound = -1
 
 var (
+	ErrFromGtTo  = errors.New("error: param from cannot be greater than param to")
 	ErrListEmpty = errors.New("error: list cannot be empty")
 	ErrSelf      = errors.New("error: param l cannot be the caller itself")
 )
+
+// checkRange checks that [from, to) is a range of a list of size elements
+func checkRange(from, to, size int) error {
+	if from < 0 {
+		return ErrIndexLtZero
+	}
+	if to > size {
+		return ErrIndexGtSize
+	}
+	if from > to {
+		return ErrFromGtTo
+	}
+	return nil
+}
+
+// defaultEqual is the equality of the zero value lists and of the lists created with a nil
+// equality function, like ==, it panics if the dynamic type of the elements is not comparable
+func defaultEqual[E any](a, b E) bool {
+	return any(a) == any(b)
+}
+
+// equality is how a list compares its elements, the zero value is defaultEqual.
+// index and set are nil unless the elements can be looked up faster than by calling equal,
+// set returns a function which reports whether e equals any element of es.
+type equality[E any] struct {
+	equal func(a, b E) bool
+	index func(es []E, e E) int
+	set   func(es []E) func(e E) bool
+}
+
+// comparableEquality is the equality of the lists created by NewSliceList and NewLinkedList
+func comparableEquality[E comparable]() equality[E] {
+	return equality[E]{
+		equal: func(a, b E) bool {
+			return a == b
+		},
+		index: func(es []E, e E) int {
+			for i, v := range es {
+				if v == e {
+					return i
+				}
+			}
+			return NotFound
+		},
+		set: func(es []E) func(e E) bool {
+			set := make(map[E]struct{}, len(es))
+			for _, e := range es {
+				set[e] = struct{}{}
+			}
+			return func(e E) bool {
+				_, ok := set[e]
+				return ok
+			}
+		},
+	}
+}
+
+func (eq equality[E]) eq(a, b E) bool {
+	if eq.equal == nil {
+		return defaultEqual(a, b)
+	}
+	return eq.equal(a, b)
+}
+
+// indexOf returns the index of the first element of es which equals e
+func (eq equality[E]) indexOf(es []E, e E) int {
+	if eq.index != nil {
+		return eq.index(es, e)
+	}
+	for i, v := range es {
+		if eq.eq(v, e) {
+			return i
+		}
+	}
+	return NotFound
+}
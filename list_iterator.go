@@ -0,0 +1,305 @@
+package container
+
+import "errors"
+
+// ListIterator is an iterator which can move in both directions and modify the list while iterating.
+// Its cursor lies between the element which Previous would return and the one which Next would return.
+// Remove and Set apply to the element returned by the last call to Next or Previous.
+type ListIterator[E any] interface {
+	Iterator[E]
+	HasPrevious() bool
+	Previous() E
+	NextIndex() int
+	PreviousIndex() int
+	Remove() error
+	Set(e E) error
+	Add(e E) error
+}
+
+var ErrIteratorState = errors.New("error: Next or Previous must be called before Remove or Set")
+
+// sqListIterator is a ListIterator over a list which can be accessed by index
+type sqListIterator[E any] struct {
+	l        subListParent[E]
+	cursor   int
+	lastRet  int
+	modCount int
+	err      error
+}
+
+// newSqListIterator must be called with the lock of l held
+func newSqListIterator[E any](l subListParent[E]) *sqListIterator[E] {
+	return &sqListIterator[E]{
+		l:        l,
+		lastRet:  -1,
+		modCount: l.getModCount(),
+	}
+}
+
+// check must be called with the lock of it.l held
+func (it *sqListIterator[E]) check() bool {
+	if it.err == nil && it.l.getModCount() != it.modCount {
+		it.err = ErrConcurrentModification
+	}
+	return it.err == nil
+}
+
+func (it *sqListIterator[E]) HasNext() bool {
+	rw := it.l.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	return it.check() && it.cursor < it.l.size()
+}
+
+func (it *sqListIterator[E]) Next() E {
+	rw := it.l.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	var e E
+	if !it.check() || it.cursor >= it.l.size() {
+		return e
+	}
+	e = it.l.getAt(it.cursor)
+	it.lastRet = it.cursor
+	it.cursor++
+	return e
+}
+
+func (it *sqListIterator[E]) HasPrevious() bool {
+	rw := it.l.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	return it.check() && it.cursor > 0
+}
+
+func (it *sqListIterator[E]) Previous() E {
+	rw := it.l.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	var e E
+	if !it.check() || it.cursor <= 0 || it.cursor > it.l.size() {
+		return e
+	}
+	it.cursor--
+	it.lastRet = it.cursor
+	return it.l.getAt(it.cursor)
+}
+
+func (it *sqListIterator[E]) NextIndex() int {
+	return it.cursor
+}
+
+func (it *sqListIterator[E]) PreviousIndex() int {
+	return it.cursor - 1
+}
+
+func (it *sqListIterator[E]) Remove() error {
+	rw := it.l.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if !it.check() {
+		return it.err
+	}
+	if it.lastRet < 0 {
+		return ErrIteratorState
+	}
+	it.l.removeAt(it.lastRet)
+	if it.lastRet < it.cursor {
+		it.cursor--
+	}
+	it.lastRet = -1
+	it.modCount = it.l.getModCount()
+	return nil
+}
+
+func (it *sqListIterator[E]) Set(e E) error {
+	rw := it.l.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if !it.check() {
+		return it.err
+	}
+	if it.lastRet < 0 {
+		return ErrIteratorState
+	}
+	return it.l.setAt(it.lastRet, e)
+}
+
+func (it *sqListIterator[E]) Add(e E) error {
+	rw := it.l.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if !it.check() {
+		return it.err
+	}
+	if err := it.l.insertAt(it.cursor, e); err != nil {
+		return err
+	}
+	it.cursor++
+	it.lastRet = -1
+	it.modCount = it.l.getModCount()
+	return nil
+}
+
+func (it *sqListIterator[E]) Err() error {
+	return it.err
+}
+
+// linkedListIterator is a ListIterator which walks the nodes of a LinkedList,
+// next is the node which Next would return, it is the sentinel at the end of the list.
+// If view is not nil the iterator walks the elements of the view, index is relative to it.
+type linkedListIterator[E any] struct {
+	ll       *LinkedList[E]
+	view     *subList[E]
+	next     *LinkedNode[E]
+	lastRet  *LinkedNode[E]
+	index    int
+	modCount int
+	err      error
+}
+
+// newLinkedListIterator must be called with the lock of ll held
+func newLinkedListIterator[E any](ll *LinkedList[E]) *linkedListIterator[E] {
+	return &linkedListIterator[E]{
+		ll:       ll,
+		next:     ll.head.next,
+		modCount: ll.modCount,
+	}
+}
+
+// newLinkedListViewIterator returns an iterator over view, which starts at index offset of ll,
+// with its cursor at index of the view. It must be called with the lock of ll held.
+func newLinkedListViewIterator[E any](ll *LinkedList[E], view *subList[E], offset, index int) *linkedListIterator[E] {
+	it := newLinkedListIterator[E](ll)
+	it.view = view
+	it.next = ll.getNode(offset + index)
+	it.index = index
+	return it
+}
+
+func (it *linkedListIterator[E]) hasNext() bool {
+	if it.view != nil {
+		return it.index < it.view.len
+	}
+	return it.next != &it.ll.head
+}
+
+func (it *linkedListIterator[E]) hasPrevious() bool {
+	if it.view != nil {
+		return it.index > 0
+	}
+	return it.next.prev != &it.ll.head
+}
+
+// modified records a structural modification made by the iterator, delta is the change of the size
+func (it *linkedListIterator[E]) modified(delta int) {
+	if it.view != nil {
+		it.view.resync(delta)
+	}
+	it.modCount = it.ll.modCount
+}
+
+// check must be called with the lock of it.ll held, before following the links of the nodes
+func (it *linkedListIterator[E]) check() bool {
+	if it.err == nil && it.ll.modCount != it.modCount {
+		it.err = ErrConcurrentModification
+	}
+	return it.err == nil
+}
+
+func (it *linkedListIterator[E]) HasNext() bool {
+	it.ll.rw.RLock()
+	defer it.ll.rw.RUnlock()
+	return it.check() && it.hasNext()
+}
+
+func (it *linkedListIterator[E]) Next() E {
+	it.ll.rw.RLock()
+	defer it.ll.rw.RUnlock()
+	var e E
+	if !it.check() || !it.hasNext() {
+		return e
+	}
+	it.lastRet = it.next
+	it.next = it.next.next
+	it.index++
+	return it.lastRet.e
+}
+
+func (it *linkedListIterator[E]) HasPrevious() bool {
+	it.ll.rw.RLock()
+	defer it.ll.rw.RUnlock()
+	return it.check() && it.hasPrevious()
+}
+
+func (it *linkedListIterator[E]) Previous() E {
+	it.ll.rw.RLock()
+	defer it.ll.rw.RUnlock()
+	var e E
+	if !it.check() || !it.hasPrevious() {
+		return e
+	}
+	it.next = it.next.prev
+	it.lastRet = it.next
+	it.index--
+	return it.lastRet.e
+}
+
+func (it *linkedListIterator[E]) NextIndex() int {
+	return it.index
+}
+
+func (it *linkedListIterator[E]) PreviousIndex() int {
+	return it.index - 1
+}
+
+func (it *linkedListIterator[E]) Remove() error {
+	it.ll.rw.Lock()
+	defer it.ll.rw.Unlock()
+	if !it.check() {
+		return it.err
+	}
+	if it.lastRet == nil {
+		return ErrIteratorState
+	}
+	if it.next == it.lastRet {
+		it.next = it.lastRet.next
+	} else {
+		it.index--
+	}
+	it.ll.removeNode(it.lastRet)
+	it.lastRet = nil
+	it.modified(-1)
+	return nil
+}
+
+func (it *linkedListIterator[E]) Set(e E) error {
+	it.ll.rw.Lock()
+	defer it.ll.rw.Unlock()
+	if !it.check() {
+		return it.err
+	}
+	if it.lastRet == nil {
+		return ErrIteratorState
+	}
+	it.ll.snaps.preserve()
+	it.lastRet.e = e
+	return nil
+}
+
+func (it *linkedListIterator[E]) Add(e E) error {
+	it.ll.rw.Lock()
+	defer it.ll.rw.Unlock()
+	if !it.check() {
+		return it.err
+	}
+	it.ll.insertBefore(it.next, e)
+	it.index++
+	it.lastRet = nil
+	it.modified(1)
+	return nil
+}
+
+func (it *linkedListIterator[E]) Err() error {
+	return it.err
+}
@@ -0,0 +1,607 @@
+package container
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SyncPolicy decides when a PersistentQueue flushes its log to stable storage
+type SyncPolicy int
+
+const (
+	// SyncEveryOp syncs the log before every En and De returns
+	SyncEveryOp SyncPolicy = iota
+	// SyncInterval syncs the log in the background every SyncInterval
+	SyncInterval
+	// SyncNever leaves the log to the operating system
+	SyncNever
+)
+
+var (
+	ErrCorruptLog     = errors.New("error: queue log cannot be corrupted")
+	ErrRecordTooLarge = errors.New("error: record cannot be larger than the segment size")
+)
+
+// errTornRecord is a damaged record which runs to the end of its file
+var errTornRecord = errors.New("error: record cannot be torn")
+
+type PersistentQueueOptions[E any] struct {
+	// Codec defaults to GobCodec
+	Codec Codec[E]
+	// SegmentSize is the size in bytes from which a new segment file is started, 64 MiB by default.
+	// It bounds the size of a record as well, so it cannot be reduced below the largest stored element.
+	SegmentSize int64
+	Sync        SyncPolicy
+	// SyncInterval defaults to one second
+	SyncInterval time.Duration
+}
+
+const (
+	segmentExt         = ".seg"
+	defaultSegmentSize = 64 << 20
+	recordHeaderSize   = 9
+	recordEn           = 1
+	recordAck          = 2
+)
+
+// segment is a log file, base is the sequence number of its first En record
+type segment struct {
+	base uint64
+	path string
+}
+
+// PersistentQueue is a queue whose operations are appended to segmented log files in dir,
+// so that its elements survive the crash of the process.
+// Every element gets a sequence number, En appends the element and De appends the sequence number
+// of the new front, the acknowledgement watermark. Opening the queue replays the log, and the
+// segments whose elements have all been removed are deleted.
+// The elements are kept in memory as well. En records the first error, which Err returns.
+// A record torn by a crash at the end of the last segment is discarded when the queue is opened,
+// a damaged record anywhere else makes OpenPersistentQueue return ErrCorruptLog.
+// If a failed write cannot be removed from the log, every later modification returns its error.
+type PersistentQueue[E any] struct {
+	UnimplementedSqContainer[E]
+	ring        ringBuffer[E]
+	codec       Codec[E]
+	dir         string
+	segmentSize int64
+	sync        SyncPolicy
+	segs        []segment
+	file        *os.File
+	size        int64
+	nextSeq     uint64
+	dirty       bool
+	closed      bool
+	failed      error
+	err         error
+	done        chan struct{}
+	wg          sync.WaitGroup
+	modCount    int
+	rw          sync.RWMutex
+}
+
+// OpenPersistentQueue opens the queue stored in dir, creating dir if needed
+func OpenPersistentQueue[E any](dir string, opts PersistentQueueOptions[E]) (*PersistentQueue[E], error) {
+	pq := &PersistentQueue[E]{
+		codec:       opts.Codec,
+		dir:         dir,
+		segmentSize: opts.SegmentSize,
+		sync:        opts.Sync,
+	}
+	if pq.codec == nil {
+		pq.codec = GobCodec[E]{}
+	}
+	if pq.segmentSize <= 0 {
+		pq.segmentSize = defaultSegmentSize
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	if err := pq.replay(); err != nil {
+		if pq.file != nil {
+			pq.file.Close()
+		}
+		return nil, err
+	}
+	if pq.sync == SyncInterval {
+		interval := opts.SyncInterval
+		if interval <= 0 {
+			interval = time.Second
+		}
+		pq.done = make(chan struct{})
+		pq.wg.Add(1)
+		go pq.syncLoop(interval)
+	}
+	return pq, nil
+}
+
+func segmentName(base uint64) string {
+	return fmt.Sprintf("%020d%s", base, segmentExt)
+}
+
+func (pq *PersistentQueue[E]) listSegments() ([]segment, error) {
+	entries, err := os.ReadDir(pq.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segs []segment
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		base, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		segs = append(segs, segment{base: base, path: filepath.Join(pq.dir, name)})
+	}
+	sort.Slice(segs, func(i, j int) bool {
+		return segs[i].base < segs[j].base
+	})
+	return segs, nil
+}
+
+// replay rebuilds the queue from the log and opens the last segment for appending
+func (pq *PersistentQueue[E]) replay() error {
+	segs, err := pq.listSegments()
+	if err != nil {
+		return err
+	}
+	if len(segs) == 0 {
+		return pq.createSegment(0, 0)
+	}
+	pq.segs = segs
+	pq.nextSeq = segs[0].base
+	var end int64
+	for i, seg := range segs {
+		if seg.base != pq.nextSeq {
+			return ErrCorruptLog
+		}
+		last := i == len(segs)-1
+		if end, err = pq.replaySegment(seg, last); err != nil {
+			return err
+		}
+	}
+	seg := segs[len(segs)-1]
+	file, err := os.OpenFile(seg.path, os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	// drop a torn record at the end of the log
+	if err := file.Truncate(end); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Seek(end, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	pq.file = file
+	pq.size = end
+	pq.compact()
+	return nil
+}
+
+// replaySegment applies the records of seg and returns the offset of the end of its last valid record
+func (pq *PersistentQueue[E]) replaySegment(seg segment, last bool) (int64, error) {
+	file, err := os.Open(seg.path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+	r := bufio.NewReader(file)
+	var offset int64
+	for {
+		typ, payload, err := readRecord(r, pq.segmentSize, info.Size()-offset)
+		if err == io.EOF {
+			return offset, nil
+		}
+		if err == errTornRecord {
+			if last {
+				return offset, nil
+			}
+			return 0, ErrCorruptLog
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch typ {
+		case recordEn:
+			e, err := pq.codec.Decode(payload)
+			if err != nil {
+				return 0, err
+			}
+			pq.ring.pushBack(e)
+			pq.nextSeq++
+		case recordAck:
+			if len(payload) != 8 {
+				return 0, ErrCorruptLog
+			}
+			watermark := binary.LittleEndian.Uint64(payload)
+			for pq.ring.len > 0 && pq.headSeq() < watermark {
+				pq.ring.popFront()
+			}
+		default:
+			return 0, ErrCorruptLog
+		}
+		offset += recordHeaderSize + int64(len(payload))
+	}
+}
+
+// readRecord reads a record of at most limit bytes from the remaining bytes of a file.
+// It returns io.EOF at the end of the file, errTornRecord for a damaged record which runs
+// to the end of the file, and ErrCorruptLog for any other damaged record.
+// A length running past the end of the file is torn only if no valid record follows it,
+// otherwise it is a damaged length in the middle of the file.
+func readRecord(r *bufio.Reader, limit, remaining int64) (byte, []byte, error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return 0, nil, io.EOF
+		}
+		if err == io.ErrUnexpectedEOF {
+			return 0, nil, errTornRecord
+		}
+		return 0, nil, err
+	}
+	size := recordHeaderSize + int64(binary.LittleEndian.Uint32(header[1:5]))
+	if size > remaining {
+		rest, err := io.ReadAll(r)
+		if err != nil {
+			return 0, nil, err
+		}
+		if containsRecord(append(header[1:], rest...), limit) {
+			return 0, nil, ErrCorruptLog
+		}
+		return 0, nil, errTornRecord
+	}
+	if size > limit {
+		return 0, nil, ErrCorruptLog
+	}
+	payload := make([]byte, size-recordHeaderSize)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return 0, nil, errTornRecord
+		}
+		return 0, nil, err
+	}
+	if recordChecksum(header[0], payload) != binary.LittleEndian.Uint32(header[5:9]) {
+		if size == remaining {
+			return 0, nil, errTornRecord
+		}
+		return 0, nil, ErrCorruptLog
+	}
+	return header[0], payload, nil
+}
+
+// containsRecord reports whether a valid record of at most limit bytes starts anywhere in data
+func containsRecord(data []byte, limit int64) bool {
+	for i := 0; i+recordHeaderSize <= len(data); i++ {
+		typ := data[i]
+		if typ != recordEn && typ != recordAck {
+			continue
+		}
+		size := recordHeaderSize + int64(binary.LittleEndian.Uint32(data[i+1:i+5]))
+		if size > limit || int64(i)+size > int64(len(data)) {
+			continue
+		}
+		payload := data[i+recordHeaderSize : int64(i)+size]
+		if recordChecksum(typ, payload) == binary.LittleEndian.Uint32(data[i+5:i+9]) {
+			return true
+		}
+	}
+	return false
+}
+
+func recordChecksum(typ byte, payload []byte) uint32 {
+	return crc32.Update(crc32.ChecksumIEEE([]byte{typ}), crc32.IEEETable, payload)
+}
+
+// headSeq returns the sequence number of the front element
+func (pq *PersistentQueue[E]) headSeq() uint64 {
+	return pq.nextSeq - uint64(pq.ring.len)
+}
+
+// createSegment starts the segment of base, which begins with the watermark
+// so that the acknowledgements survive the deletion of the previous segments
+func (pq *PersistentQueue[E]) createSegment(base, watermark uint64) error {
+	path := filepath.Join(pq.dir, segmentName(base))
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if pq.file != nil {
+		if pq.sync != SyncNever {
+			pq.file.Sync()
+		}
+		pq.file.Close()
+	}
+	pq.file = file
+	pq.size = 0
+	pq.segs = append(pq.segs, segment{base: base, path: path})
+	if pq.sync != SyncNever {
+		syncDir(pq.dir)
+	}
+	return pq.writeAck(watermark)
+}
+
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
+
+// writeRecord appends a record to the active segment, starting a new segment first
+// if the active one is full and holds at least one element.
+// A record which cannot be written is cut from the segment, if that fails too the queue is failed.
+func (pq *PersistentQueue[E]) writeRecord(typ byte, payload []byte) error {
+	if pq.failed != nil {
+		return pq.failed
+	}
+	if recordHeaderSize+int64(len(payload)) > pq.segmentSize {
+		return ErrRecordTooLarge
+	}
+	if pq.size >= pq.segmentSize && pq.nextSeq > pq.segs[len(pq.segs)-1].base {
+		if err := pq.createSegment(pq.nextSeq, pq.headSeq()); err != nil {
+			return err
+		}
+	}
+	record := make([]byte, recordHeaderSize+len(payload))
+	record[0] = typ
+	binary.LittleEndian.PutUint32(record[1:5], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[5:9], recordChecksum(typ, payload))
+	copy(record[recordHeaderSize:], payload)
+	if _, err := pq.file.Write(record); err != nil {
+		return pq.rollback(err)
+	}
+	if pq.sync == SyncEveryOp {
+		if err := pq.file.Sync(); err != nil {
+			return pq.rollback(err)
+		}
+	} else {
+		pq.dirty = true
+	}
+	pq.size += int64(len(record))
+	return nil
+}
+
+// rollback cuts the active segment back to the end of the last record written after err,
+// and fails the queue with err if it cannot
+func (pq *PersistentQueue[E]) rollback(err error) error {
+	if terr := pq.file.Truncate(pq.size); terr != nil {
+		pq.failed = err
+		return err
+	}
+	if _, serr := pq.file.Seek(pq.size, io.SeekStart); serr != nil {
+		pq.failed = err
+	}
+	return err
+}
+
+func (pq *PersistentQueue[E]) writeAck(watermark uint64) error {
+	var payload [8]byte
+	binary.LittleEndian.PutUint64(payload[:], watermark)
+	return pq.writeRecord(recordAck, payload[:])
+}
+
+// compact deletes the segments whose elements have all been removed
+func (pq *PersistentQueue[E]) compact() {
+	for len(pq.segs) > 1 && pq.segs[1].base <= pq.headSeq() {
+		if err := os.Remove(pq.segs[0].path); err != nil && !os.IsNotExist(err) {
+			return
+		}
+		pq.segs = pq.segs[1:]
+	}
+}
+
+func (pq *PersistentQueue[E]) syncLoop(interval time.Duration) {
+	defer pq.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-pq.done:
+			return
+		case <-ticker.C:
+			pq.rw.Lock()
+			if pq.dirty && !pq.closed {
+				if err := pq.file.Sync(); err == nil {
+					pq.dirty = false
+				} else if pq.err == nil {
+					pq.err = err
+				}
+			}
+			pq.rw.Unlock()
+		}
+	}
+}
+
+// Clear removes all the elements with a single acknowledgement
+func (pq *PersistentQueue[E]) Clear() {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	if pq.closed || pq.ring.len == 0 {
+		return
+	}
+	if err := pq.writeAck(pq.nextSeq); err != nil {
+		if pq.err == nil {
+			pq.err = err
+		}
+		return
+	}
+	pq.ring.clear()
+	pq.modCount++
+	pq.compact()
+}
+
+func (pq *PersistentQueue[E]) Get(i int) (E, error) {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	var e E
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= pq.ring.len {
+		return e, ErrIndexGteSize
+	}
+	return pq.ring.at(i), nil
+}
+
+func (pq *PersistentQueue[E]) IsEmpty() bool {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return pq.ring.len == 0
+}
+
+func (pq *PersistentQueue[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](pq)
+}
+
+func (pq *PersistentQueue[E]) ModCount() int {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return pq.modCount
+}
+
+func (pq *PersistentQueue[E]) Size() int {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return pq.ring.len
+}
+
+func (pq *PersistentQueue[E]) SnapshotIterator() Iterator[E] {
+	return NewSliceIterator[E](pq.ToSlice())
+}
+
+func (pq *PersistentQueue[E]) ToSlice() []E {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return pq.ring.toSlice()
+}
+
+// En is Put without the error, the error is kept for Err
+func (pq *PersistentQueue[E]) En(e E) {
+	if err := pq.Put(e); err != nil {
+		pq.rw.Lock()
+		if pq.err == nil {
+			pq.err = err
+		}
+		pq.rw.Unlock()
+	}
+}
+
+// Put appends e to the log before adding it to the rear of the queue
+func (pq *PersistentQueue[E]) Put(e E) error {
+	payload, err := pq.codec.Encode(e)
+	if err != nil {
+		return err
+	}
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	if pq.closed {
+		return ErrQueueClosed
+	}
+	if err := pq.writeRecord(recordEn, payload); err != nil {
+		return err
+	}
+	pq.ring.pushBack(e)
+	pq.nextSeq++
+	pq.modCount++
+	return nil
+}
+
+// De acknowledges the front element in the log before removing it
+func (pq *PersistentQueue[E]) De() (E, error) {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	var e E
+	if pq.closed {
+		return e, ErrQueueClosed
+	}
+	if pq.ring.len == 0 {
+		return e, ErrQueueEmpty
+	}
+	if err := pq.writeAck(pq.headSeq() + 1); err != nil {
+		return e, err
+	}
+	e = pq.ring.popFront()
+	pq.modCount++
+	pq.compact()
+	return e, nil
+}
+
+func (pq *PersistentQueue[E]) GetFront() (E, error) {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	var e E
+	if pq.ring.len == 0 {
+		return e, ErrQueueEmpty
+	}
+	return pq.ring.front(), nil
+}
+
+func (pq *PersistentQueue[E]) GetRear() (E, error) {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	var e E
+	if pq.ring.len == 0 {
+		return e, ErrQueueEmpty
+	}
+	return pq.ring.back(), nil
+}
+
+// Err returns the first error of En and of the background sync
+func (pq *PersistentQueue[E]) Err() error {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return pq.err
+}
+
+// Close syncs and closes the log, the queue cannot be modified afterwards
+func (pq *PersistentQueue[E]) Close() error {
+	pq.rw.Lock()
+	if pq.closed {
+		pq.rw.Unlock()
+		return nil
+	}
+	pq.closed = true
+	done := pq.done
+	pq.rw.Unlock()
+	if done != nil {
+		close(done)
+		pq.wg.Wait()
+	}
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	var err error
+	if pq.sync != SyncNever {
+		err = pq.file.Sync()
+	}
+	if cerr := pq.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (pq *PersistentQueue[E]) String() string {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return fmt.Sprint(pq.ring.toSlice())
+}
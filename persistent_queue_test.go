@@ -0,0 +1,228 @@
+package container
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func openTestQueue(t *testing.T, dir string, segmentSize int64) *PersistentQueue[int] {
+	t.Helper()
+	pq, err := OpenPersistentQueue(dir, PersistentQueueOptions[int]{
+		Codec:       JSONCodec[int]{},
+		SegmentSize: segmentSize,
+		Sync:        SyncNever,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pq
+}
+
+func putAll(t *testing.T, pq *PersistentQueue[int], es ...int) {
+	t.Helper()
+	for _, e := range es {
+		if err := pq.Put(e); err != nil {
+			t.Fatalf("Put(%d) = %v", e, err)
+		}
+	}
+}
+
+func segmentFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+segmentExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return paths
+}
+
+func TestPersistentQueueReplay(t *testing.T) {
+	dir := t.TempDir()
+	pq := openTestQueue(t, dir, 0)
+	putAll(t, pq, 1, 2, 3, 4, 5)
+	for _, want := range []int{1, 2} {
+		if got, err := pq.De(); err != nil || got != want {
+			t.Fatalf("De = %v, %v, want %v", got, err, want)
+		}
+	}
+	if err := pq.Close(); err != nil {
+		t.Fatal(err)
+	}
+	pq = openTestQueue(t, dir, 0)
+	defer pq.Close()
+	if got := pq.ToSlice(); !reflect.DeepEqual(got, []int{3, 4, 5}) {
+		t.Fatalf("replayed %v, want [3 4 5]", got)
+	}
+	putAll(t, pq, 6)
+	if got, err := pq.GetRear(); err != nil || got != 6 {
+		t.Fatalf("GetRear = %v, %v, want 6", got, err)
+	}
+}
+
+func TestPersistentQueueRotationAndCompaction(t *testing.T) {
+	dir := t.TempDir()
+	pq := openTestQueue(t, dir, 64)
+	es := make([]int, 30)
+	for i := range es {
+		es[i] = i
+	}
+	putAll(t, pq, es...)
+	segments := len(segmentFiles(t, dir))
+	if segments < 3 {
+		t.Fatalf("%d segments after 30 records of at most 64 bytes", segments)
+	}
+	if err := pq.Close(); err != nil {
+		t.Fatal(err)
+	}
+	pq = openTestQueue(t, dir, 64)
+	if got := pq.ToSlice(); !reflect.DeepEqual(got, es) {
+		t.Fatalf("replayed %v, want %v", got, es)
+	}
+	for i := 0; i < 25; i++ {
+		if _, err := pq.De(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := len(segmentFiles(t, dir)); got >= segments {
+		t.Fatalf("%d segments after removing 25 elements, want fewer than %d", got, segments)
+	}
+	if err := pq.Close(); err != nil {
+		t.Fatal(err)
+	}
+	pq = openTestQueue(t, dir, 64)
+	defer pq.Close()
+	if got := pq.ToSlice(); !reflect.DeepEqual(got, es[25:]) {
+		t.Fatalf("replayed %v after compaction, want %v", got, es[25:])
+	}
+}
+
+func TestPersistentQueueTornTail(t *testing.T) {
+	tests := []struct {
+		name    string
+		garbage []byte
+	}{
+		{"short header", []byte{recordEn, 4, 0}},
+		{"short payload", []byte{recordEn, 4, 0, 0, 0, 1, 2, 3, 4, '7'}},
+		{"bad checksum", []byte{recordEn, 1, 0, 0, 0, 1, 2, 3, 4, '7'}},
+		{"damaged length", []byte{recordEn, 0xff, 0xff, 0, 0, 1, 2, 3, 4, '7', '8'}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			pq := openTestQueue(t, dir, 0)
+			putAll(t, pq, 1, 2)
+			if err := pq.Close(); err != nil {
+				t.Fatal(err)
+			}
+			paths := segmentFiles(t, dir)
+			appendFile(t, paths[len(paths)-1], tt.garbage)
+			pq = openTestQueue(t, dir, 0)
+			putAll(t, pq, 3)
+			if err := pq.Close(); err != nil {
+				t.Fatal(err)
+			}
+			pq = openTestQueue(t, dir, 0)
+			defer pq.Close()
+			if got := pq.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+				t.Fatalf("replayed %v, want [1 2 3]", got)
+			}
+		})
+	}
+}
+
+func appendFile(t *testing.T, path string, data []byte) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPersistentQueueCorruptLog(t *testing.T) {
+	// the first En record follows the watermark, which has a payload of 8 bytes
+	const first = recordHeaderSize + 8
+	tests := []struct {
+		name    string
+		last    bool
+		corrupt func(data []byte)
+	}{
+		{"payload in the last segment", true, func(data []byte) { data[first+recordHeaderSize] ^= 0xff }},
+		{"payload in the first segment", false, func(data []byte) { data[first+recordHeaderSize] ^= 0xff }},
+		// the length runs past the end of the file but valid records follow it
+		{"length in the last segment", true, func(data []byte) { data[first+4] = 0x7f }},
+		{"length in the first segment", false, func(data []byte) { data[first+4] = 0x7f }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			pq := openTestQueue(t, dir, 64)
+			putAll(t, pq, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19)
+			if err := pq.Close(); err != nil {
+				t.Fatal(err)
+			}
+			paths := segmentFiles(t, dir)
+			if len(paths) < 2 {
+				t.Fatalf("%d segments, want several", len(paths))
+			}
+			path := paths[0]
+			if tt.last {
+				path = paths[len(paths)-1]
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.corrupt(data)
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := OpenPersistentQueue(dir, PersistentQueueOptions[int]{
+				Codec:       JSONCodec[int]{},
+				SegmentSize: 64,
+			}); !errors.Is(err, ErrCorruptLog) {
+				t.Fatalf("opening with %s damaged = %v, want ErrCorruptLog", filepath.Base(path), err)
+			}
+		})
+	}
+}
+
+func TestPersistentQueueRecordTooLarge(t *testing.T) {
+	pq := openTestQueue(t, t.TempDir(), 24)
+	defer pq.Close()
+	if err := pq.Put(1 << 62); !errors.Is(err, ErrRecordTooLarge) {
+		t.Fatalf("Put of a record larger than the segment = %v, want ErrRecordTooLarge", err)
+	}
+	putAll(t, pq, 1)
+}
+
+func TestPersistentQueueFailedWrite(t *testing.T) {
+	dir := t.TempDir()
+	pq := openTestQueue(t, dir, 0)
+	putAll(t, pq, 1)
+	pq.file.Close()
+	err := pq.Put(2)
+	if err == nil {
+		t.Fatal("Put to a closed file succeeded")
+	}
+	if err2 := pq.Put(3); err2 != err {
+		t.Fatalf("Put after a failed rollback = %v, want %v", err2, err)
+	}
+	if _, err2 := pq.De(); err2 != err {
+		t.Fatalf("De after a failed rollback = %v, want %v", err2, err)
+	}
+	if got := pq.ToSlice(); !reflect.DeepEqual(got, []int{1}) {
+		t.Fatalf("queue = %v, want [1]", got)
+	}
+	pq = openTestQueue(t, dir, 0)
+	defer pq.Close()
+	if got := pq.ToSlice(); !reflect.DeepEqual(got, []int{1}) {
+		t.Fatalf("replayed %v, want [1]", got)
+	}
+}
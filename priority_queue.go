@@ -0,0 +1,329 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrInvalidHandle = errors.New("error: param h does not belong to the priority queue")
+
+// PriorityHandle refers to an element inserted into a PriorityQueue,
+// it becomes invalid once the element has been removed
+type PriorityHandle[E any] struct {
+	e     E
+	index int
+	seq   uint64
+	pq    *PriorityQueue[E]
+}
+
+// PriorityQueue is a binary heap ordered by less, De removes the element which is less than all the others.
+// Get, Iterator and ToSlice visit the elements in heap order rather than in priority order.
+type PriorityQueue[E any] struct {
+	UnimplementedSqContainer[E]
+	items    []*PriorityHandle[E]
+	less     func(a, b E) bool
+	stable   bool
+	seq      uint64
+	modCount int
+	rw       sync.RWMutex
+}
+
+// NewPriorityQueue heapifies es in O(n)
+func NewPriorityQueue[E any](less func(a, b E) bool, es ...E) *PriorityQueue[E] {
+	return newPriorityQueue(less, false, es)
+}
+
+// NewStablePriorityQueue is NewPriorityQueue whose equal elements are removed in insertion order
+func NewStablePriorityQueue[E any](less func(a, b E) bool, es ...E) *PriorityQueue[E] {
+	return newPriorityQueue(less, true, es)
+}
+
+func newPriorityQueue[E any](less func(a, b E) bool, stable bool, es []E) *PriorityQueue[E] {
+	pq := &PriorityQueue[E]{
+		less:   less,
+		stable: stable,
+	}
+	pq.reset(es)
+	return pq
+}
+
+// reset replaces the elements with es, the handles of the previous elements become invalid
+func (pq *PriorityQueue[E]) reset(es []E) {
+	for _, h := range pq.items {
+		h.index = -1
+		h.pq = nil
+	}
+	pq.items = make([]*PriorityHandle[E], 0, len(es))
+	for _, e := range es {
+		pq.items = append(pq.items, pq.newHandle(e, len(pq.items)))
+	}
+	for i := len(pq.items)/2 - 1; i >= 0; i-- {
+		pq.down(i)
+	}
+	pq.modCount++
+}
+
+func (pq *PriorityQueue[E]) newHandle(e E, i int) *PriorityHandle[E] {
+	pq.seq++
+	return &PriorityHandle[E]{
+		e:     e,
+		index: i,
+		seq:   pq.seq,
+		pq:    pq,
+	}
+}
+
+func (pq *PriorityQueue[E]) itemLess(i, j int) bool {
+	a, b := pq.items[i], pq.items[j]
+	if pq.less(a.e, b.e) {
+		return true
+	}
+	if pq.stable && !pq.less(b.e, a.e) {
+		return a.seq < b.seq
+	}
+	return false
+}
+
+func (pq *PriorityQueue[E]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+func (pq *PriorityQueue[E]) up(i int) {
+	for i > 0 {
+		p := (i - 1) / 2
+		if !pq.itemLess(i, p) {
+			break
+		}
+		pq.swap(i, p)
+		i = p
+	}
+}
+
+func (pq *PriorityQueue[E]) down(i int) bool {
+	i0 := i
+	n := len(pq.items)
+	for {
+		l := 2*i + 1
+		if l >= n {
+			break
+		}
+		c := l
+		if r := l + 1; r < n && pq.itemLess(r, l) {
+			c = r
+		}
+		if !pq.itemLess(c, i) {
+			break
+		}
+		pq.swap(i, c)
+		i = c
+	}
+	return i > i0
+}
+
+func (pq *PriorityQueue[E]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+func (pq *PriorityQueue[E]) push(e E) *PriorityHandle[E] {
+	h := pq.newHandle(e, len(pq.items))
+	pq.items = append(pq.items, h)
+	pq.up(h.index)
+	pq.modCount++
+	return h
+}
+
+func (pq *PriorityQueue[E]) remove(i int) E {
+	n := len(pq.items) - 1
+	if i != n {
+		pq.swap(i, n)
+	}
+	h := pq.items[n]
+	pq.items[n] = nil
+	pq.items = pq.items[:n]
+	if i != n {
+		pq.fix(i)
+	}
+	h.index = -1
+	h.pq = nil
+	pq.modCount++
+	return h.e
+}
+
+func (pq *PriorityQueue[E]) owns(h *PriorityHandle[E]) bool {
+	return h != nil && h.pq == pq
+}
+
+func (pq *PriorityQueue[E]) Clear() {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	for _, h := range pq.items {
+		h.index = -1
+		h.pq = nil
+	}
+	pq.items = nil
+	pq.modCount++
+}
+
+func (pq *PriorityQueue[E]) Get(i int) (E, error) {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	var e E
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= len(pq.items) {
+		return e, ErrIndexGteSize
+	}
+	return pq.items[i].e, nil
+}
+
+func (pq *PriorityQueue[E]) IsEmpty() bool {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return len(pq.items) == 0
+}
+
+func (pq *PriorityQueue[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](pq)
+}
+
+func (pq *PriorityQueue[E]) ModCount() int {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return pq.modCount
+}
+
+func (pq *PriorityQueue[E]) Size() int {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	return len(pq.items)
+}
+
+func (pq *PriorityQueue[E]) SnapshotIterator() Iterator[E] {
+	return NewSliceIterator[E](pq.ToSlice())
+}
+
+func (pq *PriorityQueue[E]) ToSlice() []E {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	es := make([]E, len(pq.items))
+	for i, h := range pq.items {
+		es[i] = h.e
+	}
+	return es
+}
+
+func (pq *PriorityQueue[E]) En(e E) {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	pq.push(e)
+}
+
+func (pq *PriorityQueue[E]) De() (E, error) {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	var e E
+	if len(pq.items) == 0 {
+		return e, ErrQueueEmpty
+	}
+	return pq.remove(0), nil
+}
+
+// GetFront returns the element which De would remove
+func (pq *PriorityQueue[E]) GetFront() (E, error) {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	var e E
+	if len(pq.items) == 0 {
+		return e, ErrQueueEmpty
+	}
+	return pq.items[0].e, nil
+}
+
+// GetRear returns the element which would be removed last, it scans the leaves of the heap in O(n)
+func (pq *PriorityQueue[E]) GetRear() (E, error) {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	var e E
+	n := len(pq.items)
+	if n == 0 {
+		return e, ErrQueueEmpty
+	}
+	rear := n / 2
+	for i := rear + 1; i < n; i++ {
+		if pq.itemLess(rear, i) {
+			rear = i
+		}
+	}
+	return pq.items[rear].e, nil
+}
+
+// Insert is En which returns a handle to the inserted element
+func (pq *PriorityQueue[E]) Insert(e E) *PriorityHandle[E] {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	return pq.push(e)
+}
+
+// Value returns the element which h refers to
+func (pq *PriorityQueue[E]) Value(h *PriorityHandle[E]) (E, error) {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	var e E
+	if !pq.owns(h) {
+		return e, ErrInvalidHandle
+	}
+	return h.e, nil
+}
+
+// Fix restores the heap order after the priority of the element which h refers to has changed,
+// which is only possible when E is a pointer or contains one
+func (pq *PriorityQueue[E]) Fix(h *PriorityHandle[E]) error {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	if !pq.owns(h) {
+		return ErrInvalidHandle
+	}
+	pq.fix(h.index)
+	pq.modCount++
+	return nil
+}
+
+// Update replaces the element which h refers to with e
+func (pq *PriorityQueue[E]) Update(h *PriorityHandle[E], e E) error {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	if !pq.owns(h) {
+		return ErrInvalidHandle
+	}
+	h.e = e
+	pq.fix(h.index)
+	pq.modCount++
+	return nil
+}
+
+// Remove removes the element which h refers to
+func (pq *PriorityQueue[E]) Remove(h *PriorityHandle[E]) (E, error) {
+	pq.rw.Lock()
+	defer pq.rw.Unlock()
+	var e E
+	if !pq.owns(h) {
+		return e, ErrInvalidHandle
+	}
+	return pq.remove(h.index), nil
+}
+
+func (pq *PriorityQueue[E]) String() string {
+	pq.rw.RLock()
+	defer pq.rw.RUnlock()
+	es := make([]E, len(pq.items))
+	for i, h := range pq.items {
+		es[i] = h.e
+	}
+	return fmt.Sprint(es)
+}
@@ -2,7 +2,7 @@ package container
 
 import "errors"
 
-type Queue[E comparable] interface {
+type Queue[E any] interface {
 	Container[E]
 	En(e E)
 	De() (E, error)
@@ -10,4 +10,8 @@ type Queue[E comparable] interface {
 	GetRear() (E, error)
 }
 
-var ErrQueueEmpty = errors.New("error: queue cannot be empty")
+var (
+	ErrQueueClosed = errors.New("error: queue has been closed")
+	ErrQueueEmpty  = errors.New("error: queue cannot be empty")
+	ErrQueueFull   = errors.New("error: queue cannot be full")
+)
@@ -0,0 +1,133 @@
+package container
+
+const minRingCap = 8
+
+// ringBuffer is a growable circular buffer, its capacity is always a power of two
+// so that physical indexes can be computed with a mask instead of a modulo.
+// It grows by doubling when full and shrinks by half when it is at most a quarter full.
+type ringBuffer[E any] struct {
+	buf  []E
+	head int
+	len  int
+}
+
+func newRingBuffer[E any](es []E) ringBuffer[E] {
+	rb := ringBuffer[E]{}
+	if len(es) > 0 {
+		rb.buf = make([]E, ringCap(len(es)))
+		rb.len = copy(rb.buf, es)
+	}
+	return rb
+}
+
+func ringCap(n int) int {
+	c := minRingCap
+	for c < n {
+		c <<= 1
+	}
+	return c
+}
+
+func (rb *ringBuffer[E]) index(i int) int {
+	return (rb.head + i) & (len(rb.buf) - 1)
+}
+
+func (rb *ringBuffer[E]) at(i int) E {
+	return rb.buf[rb.index(i)]
+}
+
+func (rb *ringBuffer[E]) set(i int, e E) {
+	rb.buf[rb.index(i)] = e
+}
+
+func (rb *ringBuffer[E]) front() E {
+	return rb.buf[rb.head]
+}
+
+func (rb *ringBuffer[E]) back() E {
+	return rb.at(rb.len - 1)
+}
+
+func (rb *ringBuffer[E]) pushBack(e E) {
+	if rb.len == len(rb.buf) {
+		rb.grow()
+	}
+	rb.buf[rb.index(rb.len)] = e
+	rb.len++
+}
+
+func (rb *ringBuffer[E]) pushFront(e E) {
+	if rb.len == len(rb.buf) {
+		rb.grow()
+	}
+	rb.head = (rb.head - 1) & (len(rb.buf) - 1)
+	rb.buf[rb.head] = e
+	rb.len++
+}
+
+func (rb *ringBuffer[E]) popFront() E {
+	var zero E
+	e := rb.buf[rb.head]
+	rb.buf[rb.head] = zero
+	rb.head = (rb.head + 1) & (len(rb.buf) - 1)
+	rb.len--
+	rb.shrink()
+	return e
+}
+
+func (rb *ringBuffer[E]) popBack() E {
+	var zero E
+	i := rb.index(rb.len - 1)
+	e := rb.buf[i]
+	rb.buf[i] = zero
+	rb.len--
+	rb.shrink()
+	return e
+}
+
+func (rb *ringBuffer[E]) grow() {
+	if len(rb.buf) == 0 {
+		rb.buf = make([]E, minRingCap)
+		rb.head = 0
+		return
+	}
+	rb.resize(len(rb.buf) << 1)
+}
+
+func (rb *ringBuffer[E]) shrink() {
+	if len(rb.buf) > minRingCap && rb.len <= len(rb.buf)>>2 {
+		rb.resize(len(rb.buf) >> 1)
+	}
+}
+
+func (rb *ringBuffer[E]) resize(c int) {
+	buf := make([]E, c)
+	rb.copyTo(buf)
+	rb.buf = buf
+	rb.head = 0
+}
+
+// copyTo copies the elements in order from front to back into dst
+func (rb *ringBuffer[E]) copyTo(dst []E) int {
+	if rb.len == 0 {
+		return 0
+	}
+	tail := rb.head + rb.len
+	if tail <= len(rb.buf) {
+		return copy(dst, rb.buf[rb.head:tail])
+	}
+	n := copy(dst, rb.buf[rb.head:])
+	return n + copy(dst[n:], rb.buf[:tail-len(rb.buf)])
+}
+
+func (rb *ringBuffer[E]) clear() {
+	rb.buf = nil
+	rb.head = 0
+	rb.len = 0
+}
+
+func (rb *ringBuffer[E]) toSlice() []E {
+	es := make([]E, rb.len)
+	rb.copyTo(es)
+	return es
+}
@@ -0,0 +1,95 @@
+package container
+
+// searcher is implemented by the lists which can search without going through Get.
+// pred must be false for a prefix of the list and true for the rest of it.
+type searcher[E any] interface {
+	// search returns the smallest index for which pred is true and the element at that index,
+	// or the list size and false if there is no such index
+	search(pred func(E) bool) (int, E, bool)
+	// insertSorted inserts e at the smallest index for which pred is true under one write lock
+	insertSorted(pred func(E) bool, e E) int
+}
+
+// search is the smallest index of l for which pred is true.
+// SliceList searches in O(log n), LinkedList scans in O(n),
+// other lists are binary searched through Get and must not be modified meanwhile.
+func search[E any](l List[E], pred func(E) bool) (int, E, bool) {
+	if s, ok := l.(searcher[E]); ok {
+		return s.search(pred)
+	}
+	i, j := 0, l.Size()
+	for i < j {
+		h := int(uint(i+j) >> 1)
+		e, err := l.Get(h)
+		if err != nil {
+			break
+		}
+		if pred(e) {
+			j = h
+		} else {
+			i = h + 1
+		}
+	}
+	e, err := l.Get(i)
+	return i, e, err == nil
+}
+
+func insertSorted[E any](l List[E], pred func(E) bool, e E) (int, error) {
+	if s, ok := l.(searcher[E]); ok {
+		return s.insertSorted(pred, e), nil
+	}
+	i, _, _ := search(l, pred)
+	if err := l.AddToIndex(i, e); err != nil {
+		return NotFound, err
+	}
+	return i, nil
+}
+
+// BinarySearch searches target in a list sorted in ascending order, it returns the position
+// where target is found, or the position where target would be inserted, and whether target is found
+func BinarySearch[E Ordered](l List[E], target E) (int, bool) {
+	i, e, ok := search(l, func(e E) bool {
+		return !(e < target)
+	})
+	return i, ok && !(target < e)
+}
+
+// BinarySearchFunc is BinarySearch for a list sorted in the order determined by cmp,
+// cmp returns a negative number if e is before target, 0 if e matches target or a positive number otherwise
+func BinarySearchFunc[E, T any](l List[E], target T, cmp func(e E, target T) int) (int, bool) {
+	i, e, ok := search(l, func(e E) bool {
+		return cmp(e, target) >= 0
+	})
+	return i, ok && cmp(e, target) == 0
+}
+
+// LowerBound returns the index of the first element which is not less than target in a sorted list
+func LowerBound[E Ordered](l List[E], target E) int {
+	i, _, _ := search(l, func(e E) bool {
+		return !(e < target)
+	})
+	return i
+}
+
+// UpperBound returns the index of the first element which is greater than target in a sorted list
+func UpperBound[E Ordered](l List[E], target E) int {
+	i, _, _ := search(l, func(e E) bool {
+		return target < e
+	})
+	return i
+}
+
+// InsertSorted inserts e into a list sorted in ascending order after the elements equal to it,
+// and returns the index of e
+func InsertSorted[E Ordered](l List[E], e E) (int, error) {
+	return insertSorted(l, func(x E) bool {
+		return e < x
+	}, e)
+}
+
+// InsertSortedFunc is InsertSorted for a list sorted in the order determined by less
+func InsertSortedFunc[E any](l List[E], e E, less func(a, b E) bool) (int, error) {
+	return insertSorted(l, func(x E) bool {
+		return less(e, x)
+	}, e)
+}
@@ -0,0 +1,13 @@
+package container
+
+// SliceBlockingQueue is a BlockingQueue backed by SliceQueue,
+// a capacity less than or equal to 0 means the queue is unbounded
+type SliceBlockingQueue[E any] struct {
+	blockingQueue[E]
+}
+
+func NewSliceBlockingQueue[E any](capacity int, es ...E) *SliceBlockingQueue[E] {
+	bq := &SliceBlockingQueue[E]{}
+	bq.init(NewSliceQueue[E](es...), capacity)
+	return bq
+}
@@ -0,0 +1,131 @@
+package container
+
+import (
+	"fmt"
+	"sync"
+)
+
+type SliceDeque[E any] struct {
+	UnimplementedSqContainer[E]
+	ring     ringBuffer[E]
+	modCount int
+	rw       sync.RWMutex
+}
+
+func NewSliceDeque[E any](es ...E) *SliceDeque[E] {
+	return &SliceDeque[E]{ring: newRingBuffer(es)}
+}
+
+func (sd *SliceDeque[E]) Clear() {
+	sd.rw.Lock()
+	defer sd.rw.Unlock()
+	sd.ring.clear()
+	sd.modCount++
+}
+
+func (sd *SliceDeque[E]) Get(i int) (E, error) {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	var e E
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= sd.ring.len {
+		return e, ErrIndexGteSize
+	}
+	return sd.ring.at(i), nil
+}
+
+func (sd *SliceDeque[E]) IsEmpty() bool {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	return sd.ring.len == 0
+}
+
+func (sd *SliceDeque[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](sd)
+}
+
+func (sd *SliceDeque[E]) ModCount() int {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	return sd.modCount
+}
+
+func (sd *SliceDeque[E]) Size() int {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	return sd.ring.len
+}
+
+func (sd *SliceDeque[E]) SnapshotIterator() Iterator[E] {
+	return NewSliceIterator[E](sd.ToSlice())
+}
+
+func (sd *SliceDeque[E]) ToSlice() []E {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	return sd.ring.toSlice()
+}
+
+func (sd *SliceDeque[E]) PushFront(e E) {
+	sd.rw.Lock()
+	defer sd.rw.Unlock()
+	sd.ring.pushFront(e)
+	sd.modCount++
+}
+
+func (sd *SliceDeque[E]) PushBack(e E) {
+	sd.rw.Lock()
+	defer sd.rw.Unlock()
+	sd.ring.pushBack(e)
+	sd.modCount++
+}
+
+func (sd *SliceDeque[E]) PopFront() (E, error) {
+	sd.rw.Lock()
+	defer sd.rw.Unlock()
+	var e E
+	if sd.ring.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	sd.modCount++
+	return sd.ring.popFront(), nil
+}
+
+func (sd *SliceDeque[E]) PopBack() (E, error) {
+	sd.rw.Lock()
+	defer sd.rw.Unlock()
+	var e E
+	if sd.ring.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	sd.modCount++
+	return sd.ring.popBack(), nil
+}
+
+func (sd *SliceDeque[E]) PeekFront() (E, error) {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	var e E
+	if sd.ring.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	return sd.ring.front(), nil
+}
+
+func (sd *SliceDeque[E]) PeekBack() (E, error) {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	var e E
+	if sd.ring.len == 0 {
+		return e, ErrDequeEmpty
+	}
+	return sd.ring.back(), nil
+}
+
+func (sd *SliceDeque[E]) String() string {
+	sd.rw.RLock()
+	defer sd.rw.RUnlock()
+	return fmt.Sprint(sd.ring.toSlice())
+}
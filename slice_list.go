@@ -2,25 +2,182 @@ package container
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 )
 
-type SliceList[E comparable] struct {
+type SliceList[E any] struct {
 	UnimplementedSqContainer[E]
-	elems []E
-	rw    sync.RWMutex
+	elems    []E
+	equality equality[E]
+	modCount int
+	rw       sync.RWMutex
 }
 
 func NewSliceList[E comparable](es ...E) *SliceList[E] {
 	return &SliceList[E]{
-		elems: es,
+		elems:    es,
+		equality: comparableEquality[E](),
 	}
 }
 
+// NewSliceListFunc returns a list which compares its elements with equal,
+// so that the elements do not need to be comparable.
+// A nil equal is ==, which panics if the dynamic type of the elements is not comparable.
+func NewSliceListFunc[E any](equal func(a, b E) bool, es ...E) *SliceList[E] {
+	return &SliceList[E]{
+		elems:    es,
+		equality: equality[E]{equal: equal},
+	}
+}
+
+func (sl *SliceList[E]) eq(a, b E) bool {
+	return sl.equality.eq(a, b)
+}
+
+func (sl *SliceList[E]) insert(i int, e E) {
+	sl.elems = append(sl.elems, e)
+	copy(sl.elems[i+1:], sl.elems[i:])
+	sl.elems[i] = e
+	sl.modCount++
+}
+
+// reset replaces the elements with es
+func (sl *SliceList[E]) reset(es []E) {
+	sl.elems = es
+	sl.modCount++
+}
+
+func (sl *SliceList[E]) rwMutex() *sync.RWMutex {
+	return &sl.rw
+}
+
+func (sl *SliceList[E]) elemEquality() equality[E] {
+	return sl.equality
+}
+
+func (sl *SliceList[E]) getModCount() int {
+	return sl.modCount
+}
+
+func (sl *SliceList[E]) size() int {
+	return len(sl.elems)
+}
+
+func (sl *SliceList[E]) getAt(i int) E {
+	return sl.elems[i]
+}
+
+func (sl *SliceList[E]) setAt(i int, e E) error {
+	sl.elems[i] = e
+	return nil
+}
+
+func (sl *SliceList[E]) insertAt(i int, e E) error {
+	sl.insert(i, e)
+	return nil
+}
+
+func (sl *SliceList[E]) forRange(from, to int, fn func(i int, e E) bool) {
+	for i := from; i < to; i++ {
+		if !fn(i, sl.elems[i]) {
+			return
+		}
+	}
+}
+
+func (sl *SliceList[E]) setRange(from int, es []E) error {
+	copy(sl.elems[from:], es)
+	return nil
+}
+
+// insertAll copies es first, as es may share its array with the list
+func (sl *SliceList[E]) insertAll(i int, es []E) error {
+	return sl.replaceRange(i, i, es)
+}
+
+func (sl *SliceList[E]) removeAt(i int) E {
+	e := sl.elems[i]
+	sl.removeRange(i, i+1)
+	return e
+}
+
+func (sl *SliceList[E]) removeRange(from, to int) {
+	n := len(sl.elems)
+	copy(sl.elems[from:], sl.elems[to:])
+	var zero E
+	for i := n - (to - from); i < n; i++ {
+		sl.elems[i] = zero
+	}
+	sl.elems = sl.elems[:n-(to-from)]
+	sl.modCount++
+}
+
+func (sl *SliceList[E]) replaceRange(from, to int, es []E) error {
+	es = append([]E(nil), es...)
+	n := len(sl.elems)
+	delta := len(es) - (to - from)
+	if delta > 0 {
+		sl.elems = append(sl.elems, make([]E, delta)...)
+		copy(sl.elems[to+delta:], sl.elems[to:n])
+	} else if delta < 0 {
+		copy(sl.elems[from+len(es):], sl.elems[to:])
+		var zero E
+		for i := n + delta; i < n; i++ {
+			sl.elems[i] = zero
+		}
+		sl.elems = sl.elems[:n+delta]
+	}
+	copy(sl.elems[from:], es)
+	if delta != 0 {
+		sl.modCount++
+	}
+	return nil
+}
+
+// removeIf removes the elements in [from, to) for which pred is true and returns their number
+func (sl *SliceList[E]) removeIf(from, to int, pred func(E) bool) int {
+	j := from
+	for i := from; i < to; i++ {
+		if !pred(sl.elems[i]) {
+			sl.elems[j] = sl.elems[i]
+			j++
+		}
+	}
+	if j < to {
+		sl.removeRange(j, to)
+	}
+	return to - j
+}
+
+func (sl *SliceList[E]) search(pred func(E) bool) (int, E, bool) {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	var e E
+	i := sort.Search(len(sl.elems), func(i int) bool {
+		return pred(sl.elems[i])
+	})
+	if i == len(sl.elems) {
+		return i, e, false
+	}
+	return i, sl.elems[i], true
+}
+
+func (sl *SliceList[E]) insertSorted(pred func(E) bool, e E) int {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	i := sort.Search(len(sl.elems), func(i int) bool {
+		return pred(sl.elems[i])
+	})
+	sl.insert(i, e)
+	return i
+}
+
 func (sl *SliceList[E]) Clear() {
 	sl.rw.Lock()
 	defer sl.rw.Unlock()
 	sl.elems = nil
+	sl.modCount++
 }
 
 func (sl *SliceList[E]) Get(i int) (E, error) {
@@ -43,9 +200,13 @@ func (sl *SliceList[E]) IsEmpty() bool {
 }
 
 func (sl *SliceList[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](sl)
+}
+
+func (sl *SliceList[E]) ModCount() int {
 	sl.rw.RLock()
 	defer sl.rw.RUnlock()
-	return NewSqIterator[E](sl)
+	return sl.modCount
 }
 
 func (sl *SliceList[E]) Size() int {
@@ -54,6 +215,10 @@ func (sl *SliceList[E]) Size() int {
 	return len(sl.elems)
 }
 
+func (sl *SliceList[E]) SnapshotIterator() Iterator[E] {
+	return NewSliceIterator[E](sl.ToSlice())
+}
+
 func (sl *SliceList[E]) ToSlice() []E {
 	sl.rw.RLock()
 	defer sl.rw.RUnlock()
@@ -66,6 +231,13 @@ func (sl *SliceList[E]) Add(e E) {
 	sl.rw.Lock()
 	defer sl.rw.Unlock()
 	sl.elems = append(sl.elems, e)
+	sl.modCount++
+}
+
+func (sl *SliceList[E]) AddAll(es ...E) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	_ = sl.insertAll(len(sl.elems), es)
 }
 
 func (sl *SliceList[E]) AddToIndex(i int, e E) error {
@@ -77,11 +249,7 @@ func (sl *SliceList[E]) AddToIndex(i int, e E) error {
 	if i > len(sl.elems) {
 		return ErrIndexGtSize
 	}
-	sl.elems = append(sl.elems, e)
-	for j := len(sl.elems); j > i; j-- {
-		sl.elems[j] = sl.elems[j-1]
-	}
-	sl.elems[i] = e
+	sl.insert(i, e)
 	return nil
 }
 
@@ -89,16 +257,11 @@ func (sl *SliceList[E]) AddList(l List[E]) error {
 	if l == sl {
 		return ErrSelf
 	}
+	es := l.ToSlice()
 	sl.rw.Lock()
 	defer sl.rw.Unlock()
-	if a, ok := l.(*SliceList[E]); ok {
-		sl.elems = append(sl.elems, a.elems...)
-	} else {
-		iterator := l.Iterator()
-		for iterator.HasNext() {
-			sl.elems = append(sl.elems, iterator.Next())
-		}
-	}
+	sl.elems = append(sl.elems, es...)
+	sl.modCount++
 	return nil
 }
 
@@ -106,32 +269,33 @@ func (sl *SliceList[E]) AddListToIndex(i int, l List[E]) error {
 	if l == sl {
 		return ErrSelf
 	}
+	es := l.ToSlice()
 	sl.rw.Lock()
 	defer sl.rw.Unlock()
 	if i < 0 {
 		return ErrIndexLtZero
 	}
 	if i > len(sl.elems) {
-		return ErrIndexGteSize
-	}
-	if a, ok := l.(*SliceList[E]); ok {
-		sl.elems = append(sl.elems[:i], append(a.elems, sl.elems[i:]...)...)
-	} else {
-		a2 := make([]E, len(sl.elems[i:]))
-		copy(a2, sl.elems[i:])
-		iterator := l.Iterator()
-		for j := i; iterator.HasNext(); j++ {
-			sl.elems = append(sl.elems[:j], iterator.Next())
-		}
-		sl.elems = append(sl.elems, a2...)
+		return ErrIndexGtSize
 	}
-	return nil
+	return sl.insertAll(i, es)
+}
+
+func (sl *SliceList[E]) Contains(e E) bool {
+	return sl.IndexOf(e) != NotFound
+}
+
+func (sl *SliceList[E]) ContainsAll(c Container[E]) bool {
+	es := c.ToSlice()
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return containsAll(sl.elems, es, sl.equality)
 }
 
 func (sl *SliceList[E]) Copy() List[E] {
 	sl.rw.RLock()
 	defer sl.rw.RUnlock()
-	list := &SliceList[E]{}
+	list := &SliceList[E]{equality: sl.equality}
 	list.elems = make([]E, len(sl.elems))
 	copy(list.elems, sl.elems)
 	return list
@@ -140,32 +304,45 @@ func (sl *SliceList[E]) Copy() List[E] {
 func (sl *SliceList[E]) IndexOf(e E) int {
 	sl.rw.RLock()
 	defer sl.rw.RUnlock()
-	for i, v := range sl.elems {
-		if v == e {
-			return i
-		}
-	}
-	return NotFound
+	return sl.equality.indexOf(sl.elems, e)
 }
 
 func (sl *SliceList[E]) LastIndexOf(e E) int {
 	sl.rw.RLock()
 	defer sl.rw.RUnlock()
-	for i := len(sl.elems); i >= 0; i-- {
-		if e == sl.elems[i] {
+	for i := len(sl.elems) - 1; i >= 0; i-- {
+		if sl.eq(sl.elems[i], e) {
 			return i
 		}
 	}
 	return NotFound
 }
 
+func (sl *SliceList[E]) InsertSlice(i int, es []E) error {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	if i < 0 {
+		return ErrIndexLtZero
+	}
+	if i > len(sl.elems) {
+		return ErrIndexGtSize
+	}
+	return sl.insertAll(i, es)
+}
+
+func (sl *SliceList[E]) ListIterator() ListIterator[E] {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return newSqListIterator[E](sl)
+}
+
 func (sl *SliceList[E]) RemoveElements(e E) bool {
 	sl.rw.Lock()
 	defer sl.rw.Unlock()
 	success := false
 	n := len(sl.elems)
 	for i := 0; i < n; {
-		if e == sl.elems[i] {
+		if sl.eq(sl.elems[i], e) {
 			sl.elems = append(sl.elems[:i], sl.elems[i+1:]...)
 			n--
 			success = true
@@ -173,9 +350,13 @@ func (sl *SliceList[E]) RemoveElements(e E) bool {
 		}
 		i++
 	}
+	if success {
+		sl.modCount++
+	}
 	return success
 }
 
+// RemoveStart removes the first element, as LinkedList.RemoveStart does
 func (sl *SliceList[E]) RemoveStart() (E, error) {
 	sl.rw.Lock()
 	defer sl.rw.Unlock()
@@ -183,10 +364,7 @@ func (sl *SliceList[E]) RemoveStart() (E, error) {
 	if len(sl.elems) == 0 {
 		return e, ErrListEmpty
 	}
-	i := len(sl.elems) - 1
-	e = sl.elems[i]
-	sl.elems = sl.elems[:i]
-	return e, nil
+	return sl.removeAt(0), nil
 }
 
 func (sl *SliceList[E]) RemoveLast() (E, error) {
@@ -196,10 +374,7 @@ func (sl *SliceList[E]) RemoveLast() (E, error) {
 	if len(sl.elems) == 0 {
 		return e, ErrListEmpty
 	}
-	i := len(sl.elems) - 1
-	e = sl.elems[i]
-	sl.elems = sl.elems[:i]
-	return e, nil
+	return sl.removeAt(len(sl.elems) - 1), nil
 }
 
 func (sl *SliceList[E]) RemoveByIndex(i int) (E, error) {
@@ -212,9 +387,49 @@ func (sl *SliceList[E]) RemoveByIndex(i int) (E, error) {
 	if i >= len(sl.elems) {
 		return e, ErrIndexGteSize
 	}
-	e = sl.elems[i]
-	sl.elems = append(sl.elems[:i], sl.elems[i+1:]...)
-	return e, nil
+	return sl.removeAt(i), nil
+}
+
+func (sl *SliceList[E]) RemoveAll(c Container[E]) bool {
+	es := c.ToSlice()
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	return sl.removeIf(0, len(sl.elems), sl.equality.matcher(es)) > 0
+}
+
+func (sl *SliceList[E]) RemoveRange(from, to int) error {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	if err := checkRange(from, to, len(sl.elems)); err != nil {
+		return err
+	}
+	sl.removeRange(from, to)
+	return nil
+}
+
+func (sl *SliceList[E]) ReplaceRange(from, to int, es []E) error {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	if err := checkRange(from, to, len(sl.elems)); err != nil {
+		return err
+	}
+	return sl.replaceRange(from, to, es)
+}
+
+func (sl *SliceList[E]) RemoveIf(pred func(E) bool) bool {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	return sl.removeIf(0, len(sl.elems), pred) > 0
+}
+
+func (sl *SliceList[E]) RetainAll(c Container[E]) bool {
+	es := c.ToSlice()
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	contains := sl.equality.matcher(es)
+	return sl.removeIf(0, len(sl.elems), func(e E) bool {
+		return !contains(e)
+	}) > 0
 }
 
 func (sl *SliceList[E]) Set(i int, e E) error {
@@ -230,6 +445,29 @@ func (sl *SliceList[E]) Set(i int, e E) error {
 	return nil
 }
 
+// Sort sorts the list in the order determined by less with pdqsort
+func (sl *SliceList[E]) Sort(less func(a, b E) bool) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sort.Sort(sortSlice[E]{es: sl.elems, less: less})
+	sl.modCount++
+}
+
+// SortStable is Sort which keeps the original order of equal elements
+func (sl *SliceList[E]) SortStable(less func(a, b E) bool) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sort.Stable(sortSlice[E]{es: sl.elems, less: less})
+	sl.modCount++
+}
+
+// SubList returns a view of the elements in [from, to), see List.SubList
+func (sl *SliceList[E]) SubList(from, to int) (View[E], error) {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return newSubList[E](sl, from, to)
+}
+
 func (sl *SliceList[E]) String() string {
 	sl.rw.RLock()
 	defer sl.rw.RUnlock()
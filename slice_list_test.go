@@ -0,0 +1,19 @@
+package container
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRemoveStartRemovesTheFirstElement(t *testing.T) {
+	for _, l := range []List[int]{NewSliceList(1, 2, 3), NewLinkedList(1, 2, 3)} {
+		for _, want := range []int{1, 2, 3} {
+			if got, err := l.RemoveStart(); err != nil || got != want {
+				t.Fatalf("%T RemoveStart = %v, %v, want %v", l, got, err, want)
+			}
+		}
+		if _, err := l.RemoveStart(); !errors.Is(err, ErrListEmpty) {
+			t.Fatalf("%T RemoveStart on an empty list = %v, want ErrListEmpty", l, err)
+		}
+	}
+}
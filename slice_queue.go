@@ -5,20 +5,28 @@ import (
 	"sync"
 )
 
-type SliceQueue[E comparable] struct {
+type SliceQueue[E any] struct {
 	UnimplementedSqContainer[E]
-	elems []E
-	rw    sync.RWMutex
+	ring     ringBuffer[E]
+	modCount int
+	rw       sync.RWMutex
 }
 
-func NewSliceQueue[E comparable](es ...E) *SliceQueue[E] {
-	return &SliceQueue[E]{elems: es}
+func NewSliceQueue[E any](es ...E) *SliceQueue[E] {
+	return &SliceQueue[E]{ring: newRingBuffer(es)}
+}
+
+// reset replaces the elements with es
+func (sq *SliceQueue[E]) reset(es []E) {
+	sq.ring = newRingBuffer(es)
+	sq.modCount++
 }
 
 func (sq *SliceQueue[E]) Clear() {
 	sq.rw.Lock()
 	defer sq.rw.Unlock()
-	sq.elems = nil
+	sq.ring.clear()
+	sq.modCount++
 }
 
 func (sq *SliceQueue[E]) Get(i int) (E, error) {
@@ -28,81 +36,84 @@ func (sq *SliceQueue[E]) Get(i int) (E, error) {
 	if i < 0 {
 		return e, ErrIndexLtZero
 	}
-	if i >= len(sq.elems) {
+	if i >= sq.ring.len {
 		return e, ErrIndexGteSize
 	}
-	return sq.elems[i], nil
+	return sq.ring.at(i), nil
 }
 
 func (sq *SliceQueue[E]) IsEmpty() bool {
 	sq.rw.RLock()
 	defer sq.rw.RUnlock()
-	return len(sq.elems) == 0
+	return sq.ring.len == 0
 }
 
 func (sq *SliceQueue[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](sq)
+}
+
+func (sq *SliceQueue[E]) ModCount() int {
 	sq.rw.RLock()
 	defer sq.rw.RUnlock()
-	return NewSqIterator[E](sq)
+	return sq.modCount
 }
 
 func (sq *SliceQueue[E]) Size() int {
 	sq.rw.RLock()
 	defer sq.rw.RUnlock()
-	return len(sq.elems)
+	return sq.ring.len
+}
+
+func (sq *SliceQueue[E]) SnapshotIterator() Iterator[E] {
+	return NewSliceIterator[E](sq.ToSlice())
 }
 
 func (sq *SliceQueue[E]) ToSlice() []E {
 	sq.rw.RLock()
 	defer sq.rw.RUnlock()
-	es := make([]E, len(sq.elems))
-	copy(es, sq.elems)
-	return es
+	return sq.ring.toSlice()
 }
 
 func (sq *SliceQueue[E]) En(e E) {
 	sq.rw.Lock()
 	defer sq.rw.Unlock()
-	sq.elems = append(sq.elems, e)
+	sq.ring.pushBack(e)
+	sq.modCount++
 }
 
 func (sq *SliceQueue[E]) De() (E, error) {
 	sq.rw.Lock()
 	defer sq.rw.Unlock()
 	var e E
-	if len(sq.elems) == 0 {
+	if sq.ring.len == 0 {
 		return e, ErrQueueEmpty
 	}
-	e = sq.elems[0]
-	sq.elems = sq.elems[1:]
-	return e, nil
+	sq.modCount++
+	return sq.ring.popFront(), nil
 }
 
 func (sq *SliceQueue[E]) GetFront() (E, error) {
 	sq.rw.RLock()
 	defer sq.rw.RUnlock()
 	var e E
-	if len(sq.elems) == 0 {
+	if sq.ring.len == 0 {
 		return e, ErrQueueEmpty
 	}
-	e = sq.elems[0]
-	return e, nil
+	return sq.ring.front(), nil
 }
 
 func (sq *SliceQueue[E]) GetRear() (E, error) {
 	sq.rw.RLock()
 	defer sq.rw.RUnlock()
 	var e E
-	n := len(sq.elems)
-	if n == 0 {
+	if sq.ring.len == 0 {
 		return e, ErrQueueEmpty
 	}
-	e = sq.elems[n-1]
-	return e, nil
+	return sq.ring.back(), nil
 }
 
 func (sq *SliceQueue[E]) String() string {
 	sq.rw.RLock()
 	defer sq.rw.RUnlock()
-	return fmt.Sprint(sq.elems)
+	return fmt.Sprint(sq.ring.toSlice())
 }
@@ -5,22 +5,47 @@ import (
 	"sync"
 )
 
-type SliceStack[E comparable] struct {
+type SliceStack[E any] struct {
 	UnimplementedSqContainer[E]
-	elems []E
-	rw    sync.RWMutex
+	elems    []E
+	modCount int
+	rw       sync.RWMutex
 }
 
-func NewSliceStack[E comparable](es ...E) *SliceStack[E] {
+func NewSliceStack[E any](es ...E) *SliceStack[E] {
 	return &SliceStack[E]{
 		elems: es,
 	}
 }
 
+// reset replaces the elements with es, the last one ends up on the top
+func (ss *SliceStack[E]) reset(es []E) {
+	ss.elems = es
+	ss.modCount++
+}
+
+func (ss *SliceStack[E]) removeBottom() (E, error) {
+	ss.rw.Lock()
+	defer ss.rw.Unlock()
+	var e E
+	n := len(ss.elems)
+	if n == 0 {
+		return e, ErrStackEmpty
+	}
+	e = ss.elems[0]
+	copy(ss.elems, ss.elems[1:])
+	var zero E
+	ss.elems[n-1] = zero
+	ss.elems = ss.elems[:n-1]
+	ss.modCount++
+	return e, nil
+}
+
 func (ss *SliceStack[E]) Clear() {
 	ss.rw.Lock()
 	defer ss.rw.Unlock()
 	ss.elems = nil
+	ss.modCount++
 }
 
 func (ss *SliceStack[E]) Get(i int) (E, error) {
@@ -43,9 +68,13 @@ func (ss *SliceStack[E]) IsEmpty() bool {
 }
 
 func (ss *SliceStack[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](ss)
+}
+
+func (ss *SliceStack[E]) ModCount() int {
 	ss.rw.RLock()
 	defer ss.rw.RUnlock()
-	return NewSqIterator[E](ss)
+	return ss.modCount
 }
 
 func (ss *SliceStack[E]) Size() int {
@@ -54,6 +83,10 @@ func (ss *SliceStack[E]) Size() int {
 	return len(ss.elems)
 }
 
+func (ss *SliceStack[E]) SnapshotIterator() Iterator[E] {
+	return NewSliceIterator[E](ss.ToSlice())
+}
+
 func (ss *SliceStack[E]) ToSlice() []E {
 	ss.rw.RLock()
 	defer ss.rw.RUnlock()
@@ -84,6 +117,7 @@ func (ss *SliceStack[E]) Pop() (E, error) {
 	}
 	e = ss.elems[n-1]
 	ss.elems = ss.elems[:n-1]
+	ss.modCount++
 	return e, nil
 }
 
@@ -91,6 +125,7 @@ func (ss *SliceStack[E]) Push(e E) {
 	ss.rw.Lock()
 	defer ss.rw.Unlock()
 	ss.elems = append(ss.elems, e)
+	ss.modCount++
 }
 
 func (ss *SliceStack[E]) String() string {
@@ -0,0 +1,124 @@
+package container
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// drain iterates it to the end
+func drain[E any](t *testing.T, it Iterator[E]) []E {
+	t.Helper()
+	var es []E
+	for it.HasNext() {
+		es = append(es, it.Next())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return es
+}
+
+func TestSnapshotIteratorIgnoresLaterWrites(t *testing.T) {
+	list := func() *LinkedList[int] {
+		return NewLinkedList(1, 2, 3, 4)
+	}
+	tests := []struct {
+		name  string
+		c     Snapshotter[int]
+		write func(c Snapshotter[int])
+	}{
+		{"LinkedList Add", list(), func(c Snapshotter[int]) { c.(*LinkedList[int]).Add(5) }},
+		{"LinkedList Set", list(), func(c Snapshotter[int]) { c.(*LinkedList[int]).Set(1, 9) }},
+		{"LinkedList Clear", list(), func(c Snapshotter[int]) { c.(*LinkedList[int]).Clear() }},
+		{"LinkedList Sort", list(), func(c Snapshotter[int]) {
+			c.(*LinkedList[int]).Sort(func(a, b int) bool { return a > b })
+		}},
+		{"LinkedList SetValue", list(), func(c Snapshotter[int]) { c.(*LinkedList[int]).Front().SetValue(9) }},
+		{"LinkedList Concat", list(), func(c Snapshotter[int]) { NewLinkedList(0).Concat(c.(*LinkedList[int])) }},
+		{"LinkedQueue", NewLinkedQueue(1, 2, 3, 4), func(c Snapshotter[int]) {
+			q := c.(*LinkedQueue[int])
+			q.De()
+			q.En(5)
+		}},
+		{"LinkedStack", NewLinkedStack(1, 2, 3, 4), func(c Snapshotter[int]) {
+			s := c.(*LinkedStack[int])
+			s.Pop()
+			s.Push(5)
+		}},
+		{"LinkedDeque", NewLinkedDeque(1, 2, 3, 4), func(c Snapshotter[int]) {
+			d := c.(*LinkedDeque[int])
+			d.PopFront()
+			d.PushBack(5)
+		}},
+		{"SliceList", NewSliceList(1, 2, 3, 4), func(c Snapshotter[int]) { c.(*SliceList[int]).Set(0, 9) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it := tt.c.SnapshotIterator()
+			if got := it.Next(); got != 1 {
+				t.Fatalf("Next = %v, want 1", got)
+			}
+			tt.write(tt.c)
+			if got := drain(t, it); !reflect.DeepEqual(got, []int{2, 3, 4}) {
+				t.Fatalf("rest of the snapshot = %v, want [2 3 4]", got)
+			}
+		})
+	}
+}
+
+func TestLinkedSnapshotIteratorWalksWithoutCopying(t *testing.T) {
+	ll := NewLinkedList(1, 2, 3)
+	it := ll.SnapshotIterator().(*linkedSnapshotIterator[int])
+	if got := drain[int](t, it); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("snapshot = %v, want [1 2 3]", got)
+	}
+	if it.copied {
+		t.Fatal("the snapshot copied the list although nothing was written")
+	}
+	if len(ll.snaps.its) != 0 {
+		t.Fatalf("%d finished snapshots are still registered", len(ll.snaps.its))
+	}
+}
+
+func TestLinkedSnapshotIteratorOfView(t *testing.T) {
+	ll := NewLinkedList(0, 1, 2, 3, 4)
+	view, err := ll.SubList(1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := view.(Snapshotter[int]).SnapshotIterator()
+	ll.RemoveStart()
+	if got := drain(t, it); !reflect.DeepEqual(got, []int{1, 2, 3}) {
+		t.Fatalf("snapshot of the view = %v, want [1 2 3]", got)
+	}
+}
+
+func TestSnapshotIteratorConcurrentWriter(t *testing.T) {
+	ll := NewLinkedList[int]()
+	for i := 0; i < 1000; i++ {
+		ll.Add(i)
+	}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 1000; i++ {
+			ll.Add(i)
+			ll.RemoveStart()
+		}
+	}()
+	for k := 0; k < 10; k++ {
+		it := ll.SnapshotIterator()
+		n := 0
+		for it.HasNext() {
+			it.Next()
+			n++
+		}
+		// the writer adds before it removes
+		if it.Err() != nil || n != 1000 && n != 1001 {
+			t.Errorf("snapshot of %d elements, %v, want 1000 or 1001", n, it.Err())
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,42 @@
+package container
+
+// Ordered is a constraint that permits any type which supports the operators < <= >= >
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 |
+		~string
+}
+
+// Less reports whether a is less than b, it can be passed to Sort methods and NewPriorityQueue
+func Less[E Ordered](a, b E) bool {
+	return a < b
+}
+
+// Sort sorts a list of ordered elements in ascending order
+func Sort[E Ordered](l List[E]) {
+	l.Sort(Less[E])
+}
+
+// SortStable sorts a list of ordered elements in ascending order and keeps the original order of equal elements
+func SortStable[E Ordered](l List[E]) {
+	l.SortStable(Less[E])
+}
+
+// sortSlice adapts a slice and a less function to sort.Interface
+type sortSlice[E any] struct {
+	es   []E
+	less func(a, b E) bool
+}
+
+func (s sortSlice[E]) Len() int {
+	return len(s.es)
+}
+
+func (s sortSlice[E]) Less(i, j int) bool {
+	return s.less(s.es[i], s.es[j])
+}
+
+func (s sortSlice[E]) Swap(i, j int) {
+	s.es[i], s.es[j] = s.es[j], s.es[i]
+}
@@ -0,0 +1,458 @@
+package container
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var ErrSortedPosition = errors.New("error: elements of a sorted list cannot be placed by index")
+
+// SortedList is a list which keeps its elements in the order determined by less,
+// equal elements stay in insertion order. Two elements are equal if neither is less than the other.
+// The methods which place an element at an index return ErrSortedPosition.
+type SortedList[E any] struct {
+	UnimplementedSqContainer[E]
+	elems    []E
+	less     func(a, b E) bool
+	modCount int
+	rw       sync.RWMutex
+}
+
+func NewSortedList[E Ordered](es ...E) *SortedList[E] {
+	return NewSortedListFunc[E](Less[E], es...)
+}
+
+func NewSortedListFunc[E any](less func(a, b E) bool, es ...E) *SortedList[E] {
+	sl := &SortedList[E]{
+		elems: make([]E, len(es)),
+		less:  less,
+	}
+	copy(sl.elems, es)
+	sort.Stable(sortSlice[E]{es: sl.elems, less: less})
+	return sl
+}
+
+func (sl *SortedList[E]) eq(a, b E) bool {
+	return !sl.less(a, b) && !sl.less(b, a)
+}
+
+// lowerBound returns the index of the first element which is not less than e
+func (sl *SortedList[E]) lowerBound(e E) int {
+	return sort.Search(len(sl.elems), func(i int) bool {
+		return !sl.less(sl.elems[i], e)
+	})
+}
+
+// upperBound returns the index of the first element which is greater than e
+func (sl *SortedList[E]) upperBound(e E) int {
+	return sort.Search(len(sl.elems), func(i int) bool {
+		return sl.less(e, sl.elems[i])
+	})
+}
+
+func (sl *SortedList[E]) add(e E) int {
+	i := sl.upperBound(e)
+	sl.elems = append(sl.elems, e)
+	copy(sl.elems[i+1:], sl.elems[i:])
+	sl.elems[i] = e
+	sl.modCount++
+	return i
+}
+
+func (sl *SortedList[E]) rwMutex() *sync.RWMutex {
+	return &sl.rw
+}
+
+// elemEquality looks the elements up by binary search in a sorted copy
+func (sl *SortedList[E]) elemEquality() equality[E] {
+	return equality[E]{
+		equal: sl.eq,
+		set: func(es []E) func(e E) bool {
+			sorted := make([]E, len(es))
+			copy(sorted, es)
+			sort.Sort(sortSlice[E]{es: sorted, less: sl.less})
+			return func(e E) bool {
+				i := sort.Search(len(sorted), func(i int) bool {
+					return !sl.less(sorted[i], e)
+				})
+				return i < len(sorted) && !sl.less(e, sorted[i])
+			}
+		},
+	}
+}
+
+func (sl *SortedList[E]) getModCount() int {
+	return sl.modCount
+}
+
+func (sl *SortedList[E]) size() int {
+	return len(sl.elems)
+}
+
+func (sl *SortedList[E]) getAt(i int) E {
+	return sl.elems[i]
+}
+
+func (sl *SortedList[E]) setAt(int, E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) forRange(from, to int, fn func(i int, e E) bool) {
+	for i := from; i < to; i++ {
+		if !fn(i, sl.elems[i]) {
+			return
+		}
+	}
+}
+
+func (sl *SortedList[E]) setRange(int, []E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) insertAt(int, E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) insertAll(int, []E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) removeAt(i int) E {
+	e := sl.elems[i]
+	sl.removeRange(i, i+1)
+	return e
+}
+
+func (sl *SortedList[E]) removeRange(from, to int) {
+	n := len(sl.elems)
+	copy(sl.elems[from:], sl.elems[to:])
+	var zero E
+	for i := n - (to - from); i < n; i++ {
+		sl.elems[i] = zero
+	}
+	sl.elems = sl.elems[:n-(to-from)]
+	sl.modCount++
+}
+
+func (sl *SortedList[E]) replaceRange(int, int, []E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) removeIf(from, to int, pred func(E) bool) int {
+	j := from
+	for i := from; i < to; i++ {
+		if !pred(sl.elems[i]) {
+			sl.elems[j] = sl.elems[i]
+			j++
+		}
+	}
+	if j < to {
+		sl.removeRange(j, to)
+	}
+	return to - j
+}
+
+func (sl *SortedList[E]) search(pred func(E) bool) (int, E, bool) {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	var e E
+	i := sort.Search(len(sl.elems), func(i int) bool {
+		return pred(sl.elems[i])
+	})
+	if i == len(sl.elems) {
+		return i, e, false
+	}
+	return i, sl.elems[i], true
+}
+
+// insertSorted ignores pred, the position of e is determined by the order of the list
+func (sl *SortedList[E]) insertSorted(_ func(E) bool, e E) int {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	return sl.add(e)
+}
+
+func (sl *SortedList[E]) Clear() {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.elems = nil
+	sl.modCount++
+}
+
+func (sl *SortedList[E]) Get(i int) (E, error) {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	var e E
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= len(sl.elems) {
+		return e, ErrIndexGteSize
+	}
+	return sl.elems[i], nil
+}
+
+func (sl *SortedList[E]) IsEmpty() bool {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return len(sl.elems) == 0
+}
+
+func (sl *SortedList[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](sl)
+}
+
+func (sl *SortedList[E]) ModCount() int {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return sl.modCount
+}
+
+func (sl *SortedList[E]) Size() int {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return len(sl.elems)
+}
+
+func (sl *SortedList[E]) SnapshotIterator() Iterator[E] {
+	return NewSliceIterator[E](sl.ToSlice())
+}
+
+func (sl *SortedList[E]) ToSlice() []E {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	es := make([]E, len(sl.elems))
+	copy(es, sl.elems)
+	return es
+}
+
+// Add inserts e after the elements equal to it in O(log n) comparisons
+func (sl *SortedList[E]) Add(e E) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.add(e)
+}
+
+func (sl *SortedList[E]) AddAll(es ...E) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	if len(es) == 0 {
+		return
+	}
+	sl.elems = append(sl.elems, es...)
+	sort.Stable(sortSlice[E]{es: sl.elems, less: sl.less})
+	sl.modCount++
+}
+
+func (sl *SortedList[E]) AddToIndex(int, E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) AddList(l List[E]) error {
+	if l == sl {
+		return ErrSelf
+	}
+	es := l.ToSlice()
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.elems = append(sl.elems, es...)
+	sort.Stable(sortSlice[E]{es: sl.elems, less: sl.less})
+	sl.modCount++
+	return nil
+}
+
+func (sl *SortedList[E]) AddListToIndex(int, List[E]) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) Contains(e E) bool {
+	return sl.IndexOf(e) != NotFound
+}
+
+func (sl *SortedList[E]) ContainsAll(c Container[E]) bool {
+	es := c.ToSlice()
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return containsAll(sl.elems, es, sl.elemEquality())
+}
+
+func (sl *SortedList[E]) Copy() List[E] {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	list := &SortedList[E]{less: sl.less}
+	list.elems = make([]E, len(sl.elems))
+	copy(list.elems, sl.elems)
+	return list
+}
+
+func (sl *SortedList[E]) IndexOf(e E) int {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	if i := sl.lowerBound(e); i < len(sl.elems) && !sl.less(e, sl.elems[i]) {
+		return i
+	}
+	return NotFound
+}
+
+func (sl *SortedList[E]) LastIndexOf(e E) int {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	if i := sl.upperBound(e) - 1; i >= 0 && !sl.less(sl.elems[i], e) {
+		return i
+	}
+	return NotFound
+}
+
+func (sl *SortedList[E]) InsertSlice(int, []E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) ListIterator() ListIterator[E] {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return newSqListIterator[E](sl)
+}
+
+func (sl *SortedList[E]) RemoveElements(e E) bool {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	lo, hi := sl.lowerBound(e), sl.upperBound(e)
+	if lo == hi {
+		return false
+	}
+	sl.removeRange(lo, hi)
+	return true
+}
+
+func (sl *SortedList[E]) RemoveStart() (E, error) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	var e E
+	if len(sl.elems) == 0 {
+		return e, ErrListEmpty
+	}
+	return sl.removeAt(0), nil
+}
+
+func (sl *SortedList[E]) RemoveLast() (E, error) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	var e E
+	if len(sl.elems) == 0 {
+		return e, ErrListEmpty
+	}
+	return sl.removeAt(len(sl.elems) - 1), nil
+}
+
+func (sl *SortedList[E]) RemoveByIndex(i int) (E, error) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	var e E
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= len(sl.elems) {
+		return e, ErrIndexGteSize
+	}
+	return sl.removeAt(i), nil
+}
+
+func (sl *SortedList[E]) RemoveAll(c Container[E]) bool {
+	es := c.ToSlice()
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	return sl.removeIf(0, len(sl.elems), sl.elemEquality().matcher(es)) > 0
+}
+
+func (sl *SortedList[E]) RemoveRange(from, to int) error {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	if err := checkRange(from, to, len(sl.elems)); err != nil {
+		return err
+	}
+	sl.removeRange(from, to)
+	return nil
+}
+
+func (sl *SortedList[E]) ReplaceRange(int, int, []E) error {
+	return ErrSortedPosition
+}
+
+func (sl *SortedList[E]) RemoveIf(pred func(E) bool) bool {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	return sl.removeIf(0, len(sl.elems), pred) > 0
+}
+
+func (sl *SortedList[E]) RetainAll(c Container[E]) bool {
+	es := c.ToSlice()
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	contains := sl.elemEquality().matcher(es)
+	return sl.removeIf(0, len(sl.elems), func(e E) bool {
+		return !contains(e)
+	}) > 0
+}
+
+func (sl *SortedList[E]) Set(int, E) error {
+	return ErrSortedPosition
+}
+
+// Sort re-sorts the list by less, which becomes the order of the list from now on
+func (sl *SortedList[E]) Sort(less func(a, b E) bool) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.less = less
+	sort.Sort(sortSlice[E]{es: sl.elems, less: less})
+	sl.modCount++
+}
+
+// SortStable is Sort which keeps the current order of equal elements
+func (sl *SortedList[E]) SortStable(less func(a, b E) bool) {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.less = less
+	sort.Stable(sortSlice[E]{es: sl.elems, less: less})
+	sl.modCount++
+}
+
+// SubList returns a view of the elements in [from, to), see List.SubList.
+// The view cannot place elements by index either.
+func (sl *SortedList[E]) SubList(from, to int) (View[E], error) {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return newSubList[E](sl, from, to)
+}
+
+// Range returns the elements which are neither less than lo nor greater than hi
+func (sl *SortedList[E]) Range(lo, hi E) []E {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	i, j := sl.lowerBound(lo), sl.upperBound(hi)
+	if i >= j {
+		return nil
+	}
+	es := make([]E, j-i)
+	copy(es, sl.elems[i:j])
+	return es
+}
+
+// Rank returns the number of elements which are less than e
+func (sl *SortedList[E]) Rank(e E) int {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return sl.lowerBound(e)
+}
+
+// Select returns the element whose rank is k, it is the same as Get
+func (sl *SortedList[E]) Select(k int) (E, error) {
+	return sl.Get(k)
+}
+
+func (sl *SortedList[E]) String() string {
+	sl.rw.RLock()
+	defer sl.rw.RUnlock()
+	return fmt.Sprint(sl.elems)
+}
@@ -0,0 +1,542 @@
+package container
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const defaultMemoryLimit = 1 << 16
+
+type SpillQueueOptions[E any] struct {
+	// Codec defaults to GobCodec
+	Codec Codec[E]
+	// MemoryLimit is the number of elements kept in memory, half of them at the front
+	// and half of them at the rear, 65536 by default
+	MemoryLimit int
+	// Dir is where the directory of the spill files is created, os.TempDir() by default
+	Dir string
+}
+
+// spillChunk is a spill file holding count elements, last is kept for GetRear
+type spillChunk[E any] struct {
+	path  string
+	count int
+	last  E
+}
+
+// SpillQueue is a queue which keeps its front and its rear in memory and writes the elements
+// in between to temporary files, so that it can hold more elements than the memory limit.
+// Once the rear is full it is written to a new spill file, and once the front is empty
+// it is refilled from the oldest spill file. Close deletes the spill files.
+// The elements in the spill files are read back for Get, the last file read is cached,
+// so iterating reads every file once and holds one of them in memory at a time.
+type SpillQueue[E any] struct {
+	UnimplementedSqContainer[E]
+	head, tail ringBuffer[E]
+	chunks     []spillChunk[E]
+	spilled    int
+	limit      int
+	codec      Codec[E]
+	dir        string
+	seq        int
+	cachePath  string
+	cache      []E
+	pins       map[string]int
+	released   map[string]bool
+	closed     bool
+	err        error
+	modCount   int
+	rw         sync.RWMutex
+}
+
+func NewSpillQueue[E any](opts SpillQueueOptions[E]) (*SpillQueue[E], error) {
+	dir, err := os.MkdirTemp(opts.Dir, "spill-queue-")
+	if err != nil {
+		return nil, err
+	}
+	sq := &SpillQueue[E]{
+		codec: opts.Codec,
+		limit: opts.MemoryLimit / 2,
+		dir:   dir,
+	}
+	if sq.codec == nil {
+		sq.codec = GobCodec[E]{}
+	}
+	if opts.MemoryLimit <= 0 {
+		sq.limit = defaultMemoryLimit / 2
+	}
+	if sq.limit < 1 {
+		sq.limit = 1
+	}
+	return sq, nil
+}
+
+func (sq *SpillQueue[E]) size() int {
+	return sq.head.len + sq.spilled + sq.tail.len
+}
+
+// writeChunk writes es to a new spill file as length prefixed encoded elements
+func (sq *SpillQueue[E]) writeChunk(es []E) (spillChunk[E], error) {
+	sq.seq++
+	chunk := spillChunk[E]{
+		path:  filepath.Join(sq.dir, fmt.Sprintf("%020d.spill", sq.seq)),
+		count: len(es),
+		last:  es[len(es)-1],
+	}
+	file, err := os.Create(chunk.path)
+	if err != nil {
+		return chunk, err
+	}
+	w := bufio.NewWriter(file)
+	var prefix [binary.MaxVarintLen64]byte
+	for _, e := range es {
+		var payload []byte
+		if payload, err = sq.codec.Encode(e); err != nil {
+			break
+		}
+		if _, err = w.Write(prefix[:binary.PutUvarint(prefix[:], uint64(len(payload)))]); err != nil {
+			break
+		}
+		if _, err = w.Write(payload); err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(chunk.path)
+	}
+	return chunk, err
+}
+
+// readChunk returns the elements of chunk, caching them
+func (sq *SpillQueue[E]) readChunk(chunk spillChunk[E]) ([]E, error) {
+	if chunk.path == sq.cachePath {
+		return sq.cache, nil
+	}
+	es, err := sq.decodeChunk(chunk)
+	if err != nil {
+		return nil, err
+	}
+	sq.cachePath, sq.cache = chunk.path, es
+	return es, nil
+}
+
+// decodeChunk reads the elements of chunk, it does not need the lock
+// since a spill file is not modified once written
+func (sq *SpillQueue[E]) decodeChunk(chunk spillChunk[E]) ([]E, error) {
+	file, err := os.Open(chunk.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	r := bufio.NewReader(file)
+	es := make([]E, chunk.count)
+	for i := range es {
+		n, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, err
+		}
+		payload := make([]byte, n)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return nil, err
+		}
+		if es[i], err = sq.codec.Decode(payload); err != nil {
+			return nil, err
+		}
+	}
+	return es, nil
+}
+
+// removeChunk deletes the spill file of chunk, or leaves it to the last snapshot iterator
+// which has pinned it
+func (sq *SpillQueue[E]) removeChunk(chunk spillChunk[E]) {
+	if sq.pins[chunk.path] > 0 {
+		sq.released[chunk.path] = true
+		return
+	}
+	os.Remove(chunk.path)
+}
+
+// pin keeps the spill files of chunks until unpin, it must be called with the lock held
+func (sq *SpillQueue[E]) pin(chunks []spillChunk[E]) {
+	if sq.pins == nil {
+		sq.pins = make(map[string]int)
+		sq.released = make(map[string]bool)
+	}
+	for _, chunk := range chunks {
+		sq.pins[chunk.path]++
+	}
+}
+
+func (sq *SpillQueue[E]) unpin(chunks []spillChunk[E]) {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	for _, chunk := range chunks {
+		if sq.pins[chunk.path]--; sq.pins[chunk.path] > 0 {
+			continue
+		}
+		delete(sq.pins, chunk.path)
+		if sq.released[chunk.path] {
+			delete(sq.released, chunk.path)
+			os.Remove(chunk.path)
+		}
+	}
+}
+
+// spill writes the rear to a new spill file
+func (sq *SpillQueue[E]) spill() error {
+	chunk, err := sq.writeChunk(sq.tail.toSlice())
+	if err != nil {
+		return err
+	}
+	sq.chunks = append(sq.chunks, chunk)
+	sq.spilled += chunk.count
+	sq.tail.clear()
+	return nil
+}
+
+// refill moves the oldest spill file, or the rear if nothing has been spilled, to the empty front
+func (sq *SpillQueue[E]) refill() error {
+	if len(sq.chunks) == 0 {
+		sq.head, sq.tail = sq.tail, sq.head
+		return nil
+	}
+	chunk := sq.chunks[0]
+	es, err := sq.readChunk(chunk)
+	if err != nil {
+		return err
+	}
+	sq.head = newRingBuffer(es)
+	sq.chunks[0] = spillChunk[E]{}
+	sq.chunks = sq.chunks[1:]
+	sq.spilled -= chunk.count
+	sq.cachePath, sq.cache = "", nil
+	sq.removeChunk(chunk)
+	return nil
+}
+
+func (sq *SpillQueue[E]) removeChunks() {
+	for _, chunk := range sq.chunks {
+		sq.removeChunk(chunk)
+	}
+	sq.chunks = nil
+	sq.spilled = 0
+	sq.cachePath, sq.cache = "", nil
+}
+
+func (sq *SpillQueue[E]) Clear() {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	sq.head.clear()
+	sq.tail.clear()
+	sq.removeChunks()
+	sq.modCount++
+}
+
+// Get reads the spill file holding the element at index i if needed
+func (sq *SpillQueue[E]) Get(i int) (E, error) {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	var e E
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= sq.size() {
+		return e, ErrIndexGteSize
+	}
+	if i < sq.head.len {
+		return sq.head.at(i), nil
+	}
+	i -= sq.head.len
+	for _, chunk := range sq.chunks {
+		if i < chunk.count {
+			es, err := sq.readChunk(chunk)
+			if err != nil {
+				return e, err
+			}
+			return es[i], nil
+		}
+		i -= chunk.count
+	}
+	return sq.tail.at(i), nil
+}
+
+func (sq *SpillQueue[E]) IsEmpty() bool {
+	sq.rw.RLock()
+	defer sq.rw.RUnlock()
+	return sq.size() == 0
+}
+
+func (sq *SpillQueue[E]) Iterator() Iterator[E] {
+	return NewSqIterator[E](sq)
+}
+
+func (sq *SpillQueue[E]) ModCount() int {
+	sq.rw.RLock()
+	defer sq.rw.RUnlock()
+	return sq.modCount
+}
+
+func (sq *SpillQueue[E]) Size() int {
+	sq.rw.RLock()
+	defer sq.rw.RUnlock()
+	return sq.size()
+}
+
+// SnapshotIterator copies the elements in memory and keeps the spill files until it has finished,
+// reading them one at a time. The spill files of an abandoned iterator are kept until Close,
+// which stops the iterator with an error. A spill file which cannot be read stops the iterator
+// as well, Err of the queue reports the error too.
+func (sq *SpillQueue[E]) SnapshotIterator() Iterator[E] {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	it := &spillSnapshotIterator[E]{
+		sq:     sq,
+		es:     sq.head.toSlice(),
+		chunks: append([]spillChunk[E](nil), sq.chunks...),
+		tail:   sq.tail.toSlice(),
+	}
+	it.pinned = it.chunks
+	sq.pin(it.chunks)
+	return it
+}
+
+func (sq *SpillQueue[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	it := &spillSnapshotIterator[E]{
+		sq:      sq,
+		es:      reverse(sq.tail.toSlice()),
+		chunks:  reverse(append([]spillChunk[E](nil), sq.chunks...)),
+		tail:    reverse(sq.head.toSlice()),
+		reverse: true,
+	}
+	it.pinned = it.chunks
+	sq.pin(it.chunks)
+	return it, sq.size()
+}
+
+// ToSlice reads all the spill files, it returns nil if one of them cannot be read
+// and keeps the error for Err
+func (sq *SpillQueue[E]) ToSlice() []E {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	es := make([]E, 0, sq.size())
+	es = append(es, sq.head.toSlice()...)
+	for _, chunk := range sq.chunks {
+		ces, err := sq.readChunk(chunk)
+		if err != nil {
+			if sq.err == nil {
+				sq.err = err
+			}
+			return nil
+		}
+		es = append(es, ces...)
+	}
+	return append(es, sq.tail.toSlice()...)
+}
+
+// En is Put without the error, the error is kept for Err
+func (sq *SpillQueue[E]) En(e E) {
+	if err := sq.Put(e); err != nil {
+		sq.rw.Lock()
+		if sq.err == nil {
+			sq.err = err
+		}
+		sq.rw.Unlock()
+	}
+}
+
+// Put adds e to the rear of the queue, writing the rear to a spill file first if it is full.
+// e is not added if the spill file cannot be written.
+func (sq *SpillQueue[E]) Put(e E) error {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	if sq.closed {
+		return ErrQueueClosed
+	}
+	if len(sq.chunks) == 0 && sq.tail.len == 0 && sq.head.len < sq.limit {
+		sq.head.pushBack(e)
+		sq.modCount++
+		return nil
+	}
+	if sq.tail.len >= sq.limit {
+		if err := sq.spill(); err != nil {
+			return err
+		}
+	}
+	sq.tail.pushBack(e)
+	sq.modCount++
+	return nil
+}
+
+func (sq *SpillQueue[E]) De() (E, error) {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	var e E
+	if sq.head.len == 0 {
+		if err := sq.refill(); err != nil {
+			return e, err
+		}
+	}
+	if sq.head.len == 0 {
+		return e, ErrQueueEmpty
+	}
+	sq.modCount++
+	return sq.head.popFront(), nil
+}
+
+func (sq *SpillQueue[E]) GetFront() (E, error) {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	var e E
+	if sq.head.len == 0 {
+		if err := sq.refill(); err != nil {
+			return e, err
+		}
+	}
+	if sq.head.len == 0 {
+		return e, ErrQueueEmpty
+	}
+	return sq.head.front(), nil
+}
+
+func (sq *SpillQueue[E]) GetRear() (E, error) {
+	sq.rw.RLock()
+	defer sq.rw.RUnlock()
+	var e E
+	switch {
+	case sq.tail.len > 0:
+		return sq.tail.back(), nil
+	case len(sq.chunks) > 0:
+		return sq.chunks[len(sq.chunks)-1].last, nil
+	case sq.head.len > 0:
+		return sq.head.back(), nil
+	}
+	return e, ErrQueueEmpty
+}
+
+func (sq *SpillQueue[E]) keepErr(err error) {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	if sq.err == nil {
+		sq.err = err
+	}
+}
+
+// Err returns the first error of En and of reading the spill files
+func (sq *SpillQueue[E]) Err() error {
+	sq.rw.RLock()
+	defer sq.rw.RUnlock()
+	return sq.err
+}
+
+// Close removes all the elements and the spill files, the queue cannot be added to afterwards
+func (sq *SpillQueue[E]) Close() error {
+	sq.rw.Lock()
+	defer sq.rw.Unlock()
+	if sq.closed {
+		return nil
+	}
+	sq.closed = true
+	sq.head.clear()
+	sq.tail.clear()
+	sq.chunks = nil
+	sq.spilled = 0
+	sq.cachePath, sq.cache = "", nil
+	sq.modCount++
+	return os.RemoveAll(sq.dir)
+}
+
+// String formats the elements in memory, the spilled ones are only counted
+func (sq *SpillQueue[E]) String() string {
+	sq.rw.RLock()
+	defer sq.rw.RUnlock()
+	if sq.spilled == 0 {
+		return fmt.Sprint(append(sq.head.toSlice(), sq.tail.toSlice()...))
+	}
+	var b strings.Builder
+	b.WriteString(strings.TrimSuffix(fmt.Sprint(sq.head.toSlice()), "]"))
+	fmt.Fprintf(&b, " ...%d spilled... ", sq.spilled)
+	b.WriteString(strings.TrimPrefix(fmt.Sprint(sq.tail.toSlice()), "["))
+	return b.String()
+}
+
+// spillSnapshotIterator iterates es, then the elements of chunks one spill file at a time, then tail.
+// If reverse is true the elements of every spill file are iterated backwards.
+type spillSnapshotIterator[E any] struct {
+	sq      *SpillQueue[E]
+	es      []E
+	chunks  []spillChunk[E]
+	tail    []E
+	index   int
+	reverse bool
+	pinned  []spillChunk[E]
+	err     error
+}
+
+// advance loads the next spill file, or the tail, once es has been iterated
+func (it *spillSnapshotIterator[E]) advance() {
+	for it.index >= len(it.es) && it.err == nil {
+		if len(it.chunks) > 0 {
+			chunk := it.chunks[0]
+			it.chunks = it.chunks[1:]
+			es, err := it.sq.decodeChunk(chunk)
+			if err != nil {
+				it.err = err
+				it.es = nil
+				it.sq.keepErr(err)
+				it.release()
+				return
+			}
+			if it.reverse {
+				reverse(es)
+			}
+			it.es, it.index = es, 0
+			continue
+		}
+		if it.tail == nil {
+			it.release()
+			return
+		}
+		it.es, it.tail, it.index = it.tail, nil, 0
+	}
+}
+
+// release unpins the spill files once the iterator has finished
+func (it *spillSnapshotIterator[E]) release() {
+	if it.sq != nil {
+		it.sq.unpin(it.pinned)
+		it.sq = nil
+	}
+}
+
+func (it *spillSnapshotIterator[E]) HasNext() bool {
+	it.advance()
+	return it.index < len(it.es)
+}
+
+func (it *spillSnapshotIterator[E]) Next() E {
+	var e E
+	if !it.HasNext() {
+		return e
+	}
+	e = it.es[it.index]
+	it.index++
+	return e
+}
+
+func (it *spillSnapshotIterator[E]) Err() error {
+	return it.err
+}
@@ -0,0 +1,87 @@
+package container
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func newTestSpillQueue(t *testing.T, es ...int) *SpillQueue[int] {
+	t.Helper()
+	sq, err := NewSpillQueue(SpillQueueOptions[int]{
+		Codec:       JSONCodec[int]{},
+		MemoryLimit: 4,
+		Dir:         t.TempDir(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		sq.Close()
+	})
+	for _, e := range es {
+		if err := sq.Put(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return sq
+}
+
+func collect(t *testing.T, it Iterator[int]) []int {
+	t.Helper()
+	var es []int
+	for it.HasNext() {
+		es = append(es, it.Next())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return es
+}
+
+func TestSpillQueueSnapshotIterator(t *testing.T) {
+	want := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	sq := newTestSpillQueue(t, want...)
+	if len(sq.chunks) < 2 {
+		t.Fatalf("%d spill files, want several", len(sq.chunks))
+	}
+	it := sq.SnapshotIterator()
+	for i := 0; i < 6; i++ {
+		if _, err := sq.De(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sq.Clear()
+	if got := collect(t, it); !reflect.DeepEqual(got, want) {
+		t.Fatalf("snapshot = %v, want %v", got, want)
+	}
+	if entries, err := os.ReadDir(sq.dir); err != nil || len(entries) != 0 {
+		t.Fatalf("%d spill files left after the snapshot, %v", len(entries), err)
+	}
+}
+
+func TestSpillQueueToSliceError(t *testing.T) {
+	sq := newTestSpillQueue(t, 1, 2, 3, 4, 5, 6, 7, 8)
+	if got := sq.ToSlice(); !reflect.DeepEqual(got, []int{1, 2, 3, 4, 5, 6, 7, 8}) {
+		t.Fatalf("ToSlice = %v", got)
+	}
+	sq.cachePath, sq.cache = "", nil
+	if err := os.Remove(sq.chunks[0].path); err != nil {
+		t.Fatal(err)
+	}
+	if got := sq.ToSlice(); got != nil {
+		t.Fatalf("ToSlice with a missing spill file = %v, want nil", got)
+	}
+	if sq.Err() == nil {
+		t.Fatal("Err = nil after a spill file could not be read")
+	}
+}
+
+func TestSpillQueueString(t *testing.T) {
+	if got := newTestSpillQueue(t, 1, 2).String(); got != "[1 2]" {
+		t.Errorf("String = %q, want [1 2]", got)
+	}
+	if got := newTestSpillQueue(t, 1, 2, 3, 4, 5, 6, 7).String(); got != "[1 2 ...4 spilled... 7]" {
+		t.Errorf("String = %q", got)
+	}
+}
@@ -0,0 +1,199 @@
+package container
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// SQLEncoding is the format an SQLList is stored in
+type SQLEncoding int
+
+const (
+	// SQLJSON stores the list as a JSON array
+	SQLJSON SQLEncoding = iota
+	// SQLText stores the list as its elements joined with the delimiter
+	SQLText
+)
+
+var (
+	ErrSQLDelimiter = errors.New("error: element cannot contain the delimiter")
+	ErrSQLText      = errors.New("error: text of an element cannot have trailing characters")
+	ErrSQLType      = errors.New("error: src cannot be other than string or []byte")
+)
+
+// SQLList implements driver.Valuer and sql.Scanner around a List, so that a list can be
+// a field of the structs stored with database/sql. The zero value stores a SliceList as JSON.
+// With SQLText an empty string is an empty list, so a list holding one empty element cannot be
+// stored. Format defaults to fmt.Sprint, and Parse to fmt.Sscan except for the types whose kind
+// is string, which are kept as is. The default Parse returns ErrSQLText if it does not consume
+// the whole text of an element.
+type SQLList[E any] struct {
+	List      List[E]
+	Encoding  SQLEncoding
+	Delimiter string
+	Format    func(E) string
+	Parse     func(string) (E, error)
+}
+
+func NewSQLList[E any](l List[E], encoding SQLEncoding) *SQLList[E] {
+	return &SQLList[E]{
+		List:     l,
+		Encoding: encoding,
+	}
+}
+
+func (s SQLList[E]) delimiter() string {
+	if s.Delimiter == "" {
+		return ","
+	}
+	return s.Delimiter
+}
+
+func (s SQLList[E]) format(e E) string {
+	if s.Format != nil {
+		return s.Format(e)
+	}
+	return fmt.Sprint(e)
+}
+
+func (s SQLList[E]) parse(str string) (E, error) {
+	if s.Parse != nil {
+		return s.Parse(str)
+	}
+	var e E
+	if v := reflect.ValueOf(&e).Elem(); v.Kind() == reflect.String {
+		v.SetString(str)
+		return e, nil
+	}
+	r := strings.NewReader(str)
+	if _, err := fmt.Fscan(r, &e); err != nil {
+		return e, err
+	}
+	if strings.TrimSpace(str[len(str)-r.Len():]) != "" {
+		return e, ErrSQLText
+	}
+	return e, nil
+}
+
+// Value stores a nil List as NULL
+func (s SQLList[E]) Value() (driver.Value, error) {
+	if s.List == nil {
+		return nil, nil
+	}
+	es := s.List.ToSlice()
+	if s.Encoding == SQLJSON {
+		b, err := marshalJSON(es)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+	delim := s.delimiter()
+	strs := make([]string, len(es))
+	for i, e := range es {
+		strs[i] = s.format(e)
+		if strings.Contains(strs[i], delim) {
+			return nil, ErrSQLDelimiter
+		}
+	}
+	return strings.Join(strs, delim), nil
+}
+
+// Scan replaces the elements of List, creating a SliceList if List is nil,
+// NULL leaves the list empty. The text is parsed before List is modified,
+// then the lists of this package are replaced under a single lock and the other lists with ReplaceRange.
+func (s *SQLList[E]) Scan(src any) error {
+	var str string
+	switch v := src.(type) {
+	case nil:
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		return ErrSQLType
+	}
+	var es []E
+	if src != nil {
+		var err error
+		if s.Encoding == SQLJSON {
+			err = json.Unmarshal([]byte(str), &es)
+		} else {
+			es, err = s.parseText(str)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if s.List == nil {
+		s.List = NewSliceListFunc[E](nil)
+	}
+	if r, ok := s.List.(replacer[E]); ok {
+		return r.replaceAll(es)
+	}
+	return s.List.ReplaceRange(0, s.List.Size(), es)
+}
+
+// replacer is implemented by the lists of this package, replaceAll replaces all the elements with es
+// under a single lock
+type replacer[E any] interface {
+	replaceAll(es []E) error
+}
+
+func (sl *SliceList[E]) replaceAll(es []E) error {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	sl.reset(es)
+	return nil
+}
+
+func (ll *LinkedList[E]) replaceAll(es []E) error {
+	ll.rw.Lock()
+	defer ll.rw.Unlock()
+	ll.reset(es)
+	return nil
+}
+
+// replaceAll sorts es, it returns ErrNoLess if the list has not been created by a constructor
+func (sl *SortedList[E]) replaceAll(es []E) error {
+	sl.rw.Lock()
+	defer sl.rw.Unlock()
+	if sl.less == nil {
+		return ErrNoLess
+	}
+	sort.Stable(sortSlice[E]{es: es, less: sl.less})
+	sl.elems = es
+	sl.modCount++
+	return nil
+}
+
+func (sl *subList[E]) replaceAll(es []E) error {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	return sl.replaceRange(0, sl.len, es)
+}
+
+func (s SQLList[E]) parseText(str string) ([]E, error) {
+	if str == "" {
+		return nil, nil
+	}
+	strs := strings.Split(str, s.delimiter())
+	es := make([]E, len(strs))
+	for i, str := range strs {
+		e, err := s.parse(str)
+		if err != nil {
+			return nil, err
+		}
+		es[i] = e
+	}
+	return es, nil
+}
@@ -0,0 +1,222 @@
+package container
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is a database of a single value, "insert" stores its argument and
+// any other query returns it as a single row of a single column
+type fakeDriver struct {
+	mu    sync.Mutex
+	value driver.Value
+}
+
+type fakeConn struct {
+	d *fakeDriver
+}
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+type fakeRows struct {
+	value driver.Value
+	done  bool
+}
+
+var testDriver = &fakeDriver{}
+
+func init() {
+	sql.Register("container-fake", testDriver)
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) {
+	return fakeConn{d: d}, nil
+}
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return fakeStmt{d: c.d, query: query}, nil
+}
+
+func (c fakeConn) Close() error {
+	return nil
+}
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake driver cannot begin a transaction")
+}
+
+func (s fakeStmt) Close() error {
+	return nil
+}
+
+func (s fakeStmt) NumInput() int {
+	if s.query == "insert" {
+		return 1
+	}
+	return 0
+}
+
+func (s fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	s.d.value = args[0]
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	return &fakeRows{value: s.d.value}, nil
+}
+
+func (r *fakeRows) Columns() []string {
+	return []string{"value"}
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	dest[0] = r.value
+	r.done = true
+	return nil
+}
+
+// storeAndLoad writes src through database/sql and scans it back into dst
+func storeAndLoad(t *testing.T, src driver.Valuer, dst sql.Scanner) {
+	t.Helper()
+	db, err := sql.Open("container-fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("insert", src); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.QueryRow("select").Scan(dst); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type tag string
+
+func TestSQLListRoundTrip(t *testing.T) {
+	for _, encoding := range []SQLEncoding{SQLJSON, SQLText} {
+		src := NewSQLList[int](NewSliceList(1, -2, 3), encoding)
+		var dst SQLList[int]
+		dst.Encoding = encoding
+		storeAndLoad(t, src, &dst)
+		if got := dst.List.ToSlice(); !reflect.DeepEqual(got, []int{1, -2, 3}) {
+			t.Fatalf("encoding %d loaded %v", encoding, got)
+		}
+	}
+}
+
+func TestSQLListNamedStrings(t *testing.T) {
+	src := NewSQLList[tag](NewLinkedList[tag]("go lang", "data base"), SQLText)
+	dst := NewSQLList[tag](NewLinkedList[tag](), SQLText)
+	storeAndLoad(t, src, dst)
+	if got := dst.List.ToSlice(); !reflect.DeepEqual(got, []tag{"go lang", "data base"}) {
+		t.Fatalf("loaded %q", got)
+	}
+}
+
+func TestSQLListNull(t *testing.T) {
+	var src SQLList[int]
+	dst := NewSQLList[int](NewSliceList(1), SQLJSON)
+	storeAndLoad(t, src, dst)
+	if !dst.List.IsEmpty() {
+		t.Fatalf("NULL loaded %v", dst.List.ToSlice())
+	}
+}
+
+func TestSQLListTextErrors(t *testing.T) {
+	s := NewSQLList[int](nil, SQLText)
+	if err := s.Scan("1,2x,3"); !errors.Is(err, ErrSQLText) {
+		t.Errorf("Scan(trailing characters) = %v, want ErrSQLText", err)
+	}
+	if err := s.Scan("1, 2 ,3"); err != nil {
+		t.Errorf("Scan(spaces) = %v", err)
+	}
+	if err := s.Scan(42); !errors.Is(err, ErrSQLType) {
+		t.Errorf("Scan(int) = %v, want ErrSQLType", err)
+	}
+	strs := NewSQLList[string](NewSliceList("a,b"), SQLText)
+	if _, err := strs.Value(); !errors.Is(err, ErrSQLDelimiter) {
+		t.Errorf("Value(delimiter in element) = %v, want ErrSQLDelimiter", err)
+	}
+}
+
+func TestSQLListScanFailureKeepsTheList(t *testing.T) {
+	s := NewSQLList[int](NewLinkedList(1, 2), SQLText)
+	if err := s.Scan("3,x"); err == nil {
+		t.Fatal("Scan of an invalid element succeeded")
+	}
+	if got := s.List.ToSlice(); !reflect.DeepEqual(got, []int{1, 2}) {
+		t.Fatalf("the failed Scan changed the list to %v", got)
+	}
+}
+
+func TestSQLListScanLists(t *testing.T) {
+	view, err := NewSliceList(0, 1, 2, 3).SubList(1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		l    List[int]
+		want []int
+	}{
+		{"SortedList", NewSortedList(9), []int{1, 2, 3}},
+		{"SubList", view, []int{3, 1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := NewSQLList[int](tt.l, SQLJSON).Scan("[3,1,2]"); err != nil {
+				t.Fatal(err)
+			}
+			if got := tt.l.ToSlice(); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("scanned %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if err := NewSQLList[int](new(SortedList[int]), SQLJSON).Scan("[1]"); !errors.Is(err, ErrNoLess) {
+		t.Fatalf("Scan into the zero SortedList = %v, want ErrNoLess", err)
+	}
+}
+
+func TestSQLListScanConcurrentReader(t *testing.T) {
+	s := NewSQLList[int](NewSliceList(0, 0, 0), SQLText)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			if err := s.Scan("1,2,3"); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case <-done:
+			return
+		default:
+		}
+		if n := s.List.Size(); n != 3 {
+			t.Fatalf("a reader saw %d elements during Scan, want 3", n)
+		}
+	}
+}
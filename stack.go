@@ -4,11 +4,15 @@ import (
 	"errors"
 )
 
-type Stack[E comparable] interface {
+type Stack[E any] interface {
 	Container[E]
 	GetTop() (E, error)
 	Pop() (E, error)
 	Push(e E)
 }
 
-var ErrStackEmpty = errors.New("error: stack cannot be empty")
+var (
+	ErrStackClosed = errors.New("error: stack has been closed")
+	ErrStackEmpty  = errors.New("error: stack cannot be empty")
+	ErrStackFull   = errors.New("error: stack cannot be full")
+)
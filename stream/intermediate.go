@@ -0,0 +1,213 @@
+package stream
+
+import container "github.com/YuukiKazuto/go-container"
+
+// Map returns the results of applying f to the elements of it
+func Map[E, R any](it container.Iterator[E], f func(E) R) container.Iterator[R] {
+	return newIterator(func() (R, bool) {
+		e, ok := next(it)
+		if !ok {
+			var r R
+			return r, false
+		}
+		return f(e), true
+	}, it.Err)
+}
+
+// Filter returns the elements of it for which pred is true
+func Filter[E any](it container.Iterator[E], pred func(E) bool) container.Iterator[E] {
+	return newIterator(func() (E, bool) {
+		for {
+			e, ok := next(it)
+			if !ok || pred(e) {
+				return e, ok
+			}
+		}
+	}, it.Err)
+}
+
+// FlatMap returns the elements of the iterators which f returns for the elements of it
+func FlatMap[E, R any](it container.Iterator[E], f func(E) container.Iterator[R]) container.Iterator[R] {
+	var inner container.Iterator[R]
+	return newIterator(func() (R, bool) {
+		for {
+			if inner != nil {
+				if r, ok := next(inner); ok {
+					return r, true
+				}
+				if inner.Err() != nil {
+					var r R
+					return r, false
+				}
+			}
+			e, ok := next(it)
+			if !ok {
+				var r R
+				return r, false
+			}
+			inner = f(e)
+		}
+	}, func() error {
+		if err := it.Err(); err != nil {
+			return err
+		}
+		if inner != nil {
+			return inner.Err()
+		}
+		return nil
+	})
+}
+
+// Take returns at most the first n elements of it
+func Take[E any](it container.Iterator[E], n int) container.Iterator[E] {
+	return newIterator(func() (E, bool) {
+		if n <= 0 {
+			var e E
+			return e, false
+		}
+		n--
+		return next(it)
+	}, it.Err)
+}
+
+// Skip returns the elements of it after the first n ones
+func Skip[E any](it container.Iterator[E], n int) container.Iterator[E] {
+	return newIterator(func() (E, bool) {
+		for ; n > 0; n-- {
+			if _, ok := next(it); !ok {
+				var e E
+				return e, false
+			}
+		}
+		return next(it)
+	}, it.Err)
+}
+
+// TakeWhile returns the elements of it until pred is false for one of them
+func TakeWhile[E any](it container.Iterator[E], pred func(E) bool) container.Iterator[E] {
+	done := false
+	return newIterator(func() (E, bool) {
+		if !done {
+			if e, ok := next(it); ok && pred(e) {
+				return e, true
+			}
+			done = true
+		}
+		var e E
+		return e, false
+	}, it.Err)
+}
+
+// Chain returns the elements of its one iterator after another
+func Chain[E any](its ...container.Iterator[E]) container.Iterator[E] {
+	i := 0
+	return newIterator(func() (E, bool) {
+		for ; i < len(its); i++ {
+			if e, ok := next(its[i]); ok {
+				return e, true
+			}
+			if its[i].Err() != nil {
+				break
+			}
+		}
+		var e E
+		return e, false
+	}, func() error {
+		for _, it := range its {
+			if err := it.Err(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Zip pairs the elements of a and b, it stops when either of them is exhausted
+func Zip[A, B any](a container.Iterator[A], b container.Iterator[B]) container.Iterator[Pair[A, B]] {
+	return newIterator(func() (Pair[A, B], bool) {
+		var p Pair[A, B]
+		if !a.HasNext() || !b.HasNext() {
+			return p, false
+		}
+		p.First, p.Second = a.Next(), b.Next()
+		return p, true
+	}, func() error {
+		if err := a.Err(); err != nil {
+			return err
+		}
+		return b.Err()
+	})
+}
+
+// Enumerate pairs the elements of it with their indexes
+func Enumerate[E any](it container.Iterator[E]) container.Iterator[Pair[int, E]] {
+	i := 0
+	return newIterator(func() (Pair[int, E], bool) {
+		e, ok := next(it)
+		if !ok {
+			return Pair[int, E]{}, false
+		}
+		p := Pair[int, E]{First: i, Second: e}
+		i++
+		return p, true
+	}, it.Err)
+}
+
+// Distinct returns the elements of it which have not been returned before
+func Distinct[E comparable](it container.Iterator[E]) container.Iterator[E] {
+	seen := make(map[E]struct{})
+	return newIterator(func() (E, bool) {
+		for {
+			e, ok := next(it)
+			if !ok {
+				return e, false
+			}
+			if _, ok := seen[e]; !ok {
+				seen[e] = struct{}{}
+				return e, true
+			}
+		}
+	}, it.Err)
+}
+
+// Window returns the sliding windows of size consecutive elements of it,
+// every window is a new slice. There is no window if it has less than size elements.
+func Window[E any](it container.Iterator[E], size int) container.Iterator[[]E] {
+	var window []E
+	return newIterator(func() ([]E, bool) {
+		if size <= 0 {
+			return nil, false
+		}
+		if window == nil {
+			window = make([]E, 0, size)
+		} else {
+			window = append(make([]E, 0, size), window[1:]...)
+		}
+		for len(window) < size {
+			e, ok := next(it)
+			if !ok {
+				return nil, false
+			}
+			window = append(window, e)
+		}
+		return window, true
+	}, it.Err)
+}
+
+// Chunk returns the elements of it in slices of size elements, the last one may be shorter
+func Chunk[E any](it container.Iterator[E], size int) container.Iterator[[]E] {
+	return newIterator(func() ([]E, bool) {
+		if size <= 0 {
+			return nil, false
+		}
+		chunk := make([]E, 0, size)
+		for len(chunk) < size {
+			e, ok := next(it)
+			if !ok {
+				break
+			}
+			chunk = append(chunk, e)
+		}
+		return chunk, len(chunk) > 0
+	}, it.Err)
+}
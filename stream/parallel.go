@@ -0,0 +1,172 @@
+package stream
+
+import (
+	"context"
+	"runtime"
+	"sync"
+
+	container "github.com/YuukiKazuto/go-container"
+)
+
+// The parallel operations work on a snapshot of the list, which is split into one chunk
+// of consecutive elements per worker. A workers less than 1 means runtime.GOMAXPROCS(0).
+// The first error returned by a function, or the error of ctx, cancels the other workers
+// and is returned.
+
+// ParallelMap returns the results of applying f to the elements of l, in the order of l.
+// The results are compared with ==, see container.NewSliceListFunc.
+func ParallelMap[E, R any](ctx context.Context, l *container.SliceList[E], workers int, f func(E) (R, error)) (*container.SliceList[R], error) {
+	es := l.ToSlice()
+	rs := make([]R, len(es))
+	err := parallel(ctx, len(es), workers, func(ctx context.Context, _, from, to int) error {
+		for i := from; i < to; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			r, err := f(es[i])
+			if err != nil {
+				return err
+			}
+			rs[i] = r
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return container.NewSliceListFunc[R](nil, rs...), nil
+}
+
+// ParallelFilter returns the elements of l for which pred is true, in the order of l.
+// The elements are compared with ==, see container.NewSliceListFunc.
+func ParallelFilter[E any](ctx context.Context, l *container.SliceList[E], workers int, pred func(E) (bool, error)) (*container.SliceList[E], error) {
+	es := l.ToSlice()
+	chunks := make([][]E, chunkCount(len(es), workers))
+	err := parallel(ctx, len(es), workers, func(ctx context.Context, c, from, to int) error {
+		var kept []E
+		for i := from; i < to; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			ok, err := pred(es[i])
+			if err != nil {
+				return err
+			}
+			if ok {
+				kept = append(kept, es[i])
+			}
+		}
+		chunks[c] = kept
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, chunk := range chunks {
+		n += len(chunk)
+	}
+	kept := make([]E, 0, n)
+	for _, chunk := range chunks {
+		kept = append(kept, chunk...)
+	}
+	return container.NewSliceListFunc[E](nil, kept...), nil
+}
+
+// ParallelReduce folds every chunk of l into identity with f, then combines the results
+// of the chunks in the order of l. identity must be the identity of combine,
+// and combine must be associative.
+func ParallelReduce[E, R any](ctx context.Context, l *container.SliceList[E], workers int, identity R, f func(R, E) (R, error), combine func(R, R) R) (R, error) {
+	es := l.ToSlice()
+	rs := make([]R, chunkCount(len(es), workers))
+	err := parallel(ctx, len(es), workers, func(ctx context.Context, c, from, to int) error {
+		r := identity
+		for i := from; i < to; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var err error
+			if r, err = f(r, es[i]); err != nil {
+				return err
+			}
+		}
+		rs[c] = r
+		return nil
+	})
+	if err != nil {
+		var r R
+		return r, err
+	}
+	r := identity
+	for _, cr := range rs {
+		r = combine(r, cr)
+	}
+	return r, nil
+}
+
+// ParallelForEach calls f for every element of l, the calls of different chunks run concurrently
+func ParallelForEach[E any](ctx context.Context, l *container.SliceList[E], workers int, f func(E) error) error {
+	es := l.ToSlice()
+	return parallel(ctx, len(es), workers, func(ctx context.Context, _, from, to int) error {
+		for i := from; i < to; i++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := f(es[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// chunkCount returns the number of chunks n elements are split into
+func chunkCount(n, workers int) int {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > n {
+		return n
+	}
+	return workers
+}
+
+// parallel runs work for every chunk c of [0, n) in its own goroutine
+// and returns the first error, which cancels the ctx given to the others
+func parallel(ctx context.Context, n, workers int, work func(ctx context.Context, c, from, to int) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	chunks := chunkCount(n, workers)
+	if chunks == 0 {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	size, rest := n/chunks, n%chunks
+	from := 0
+	for c := 0; c < chunks; c++ {
+		to := from + size
+		if c < rest {
+			to++
+		}
+		wg.Add(1)
+		go func(c, from, to int) {
+			defer wg.Done()
+			if err := work(ctx, c, from, to); err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(c, from, to)
+		from = to
+	}
+	wg.Wait()
+	return firstErr
+}
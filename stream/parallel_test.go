@@ -0,0 +1,169 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	container "github.com/YuukiKazuto/go-container"
+)
+
+func numbers(n int) *container.SliceList[int] {
+	es := make([]int, n)
+	for i := range es {
+		es[i] = i
+	}
+	return container.NewSliceList(es...)
+}
+
+func TestParallelMapOrder(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 8, 200} {
+		l, err := ParallelMap(context.Background(), numbers(100), workers, func(e int) (int, error) {
+			// the later elements finish first
+			time.Sleep(time.Duration(100-e) * time.Microsecond)
+			return 2 * e, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, r := range l.ToSlice() {
+			if r != 2*i {
+				t.Fatalf("%d workers: result %d = %v, want %v", workers, i, r, 2*i)
+			}
+		}
+	}
+}
+
+func TestParallelFilterOrder(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 8, 200} {
+		l, err := ParallelFilter(context.Background(), numbers(100), workers, func(e int) (bool, error) {
+			return e%3 == 0, nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := 0
+		for _, e := range l.ToSlice() {
+			if e != want {
+				t.Fatalf("%d workers: kept %v, want %v", workers, e, want)
+			}
+			want += 3
+		}
+		if want != 102 {
+			t.Fatalf("%d workers: kept %d elements, want 34", workers, want/3)
+		}
+	}
+}
+
+func TestParallelNonComparable(t *testing.T) {
+	l := container.NewSliceListFunc[[]int](nil, []int{1}, []int{2, 3}, []int{})
+	lens, err := ParallelMap(context.Background(), l, 2, func(e []int) ([]int, error) {
+		return append(e, len(e)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := lens.ToSlice(); !reflect.DeepEqual(got, [][]int{{1, 1}, {2, 3, 2}, {0}}) {
+		t.Fatalf("ParallelMap = %v", got)
+	}
+	kept, err := ParallelFilter(context.Background(), l, 2, func(e []int) (bool, error) {
+		return len(e) > 0, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := kept.ToSlice(); !reflect.DeepEqual(got, [][]int{{1}, {2, 3}}) {
+		t.Fatalf("ParallelFilter = %v", got)
+	}
+}
+
+func TestParallelEmpty(t *testing.T) {
+	l, err := ParallelMap(context.Background(), numbers(0), 4, func(e int) (int, error) {
+		return e, nil
+	})
+	if err != nil || !l.IsEmpty() {
+		t.Fatalf("ParallelMap of an empty list = %v, %v", l, err)
+	}
+	r, err := ParallelReduce(context.Background(), numbers(0), 4, 7, func(r, e int) (int, error) {
+		return r + e, nil
+	}, func(a, b int) int {
+		return a + b
+	})
+	if err != nil || r != 7 {
+		t.Fatalf("ParallelReduce of an empty list = %v, %v, want the identity", r, err)
+	}
+}
+
+func TestParallelReduceCombinesInOrder(t *testing.T) {
+	l := container.NewSliceList("a", "b", "c", "d", "e", "f", "g")
+	r, err := ParallelReduce(context.Background(), l, 3, "", func(r, e string) (string, error) {
+		return r + e, nil
+	}, func(a, b string) string {
+		return a + b
+	})
+	if err != nil || r != "abcdefg" {
+		t.Fatalf("ParallelReduce = %q, %v, want abcdefg", r, err)
+	}
+}
+
+func TestParallelCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var calls atomic.Int32
+	err := ParallelForEach(ctx, numbers(10), 2, func(int) error {
+		calls.Add(1)
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ParallelForEach with a canceled context = %v, want context.Canceled", err)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("f called %d times with a canceled context", calls.Load())
+	}
+}
+
+func TestParallelCancelStopsWorkers(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int32
+	_, err := ParallelMap(ctx, numbers(1000), 4, func(e int) (int, error) {
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+		time.Sleep(100 * time.Microsecond)
+		return e, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ParallelMap canceled midway = %v, want context.Canceled", err)
+	}
+	if n := calls.Load(); n >= 1000 {
+		t.Fatalf("f called %d times after the context was canceled", n)
+	}
+}
+
+func TestParallelFirstError(t *testing.T) {
+	failed := make(chan struct{})
+	var calls atomic.Int32
+	_, err := ParallelFilter(context.Background(), numbers(100), 2, func(e int) (bool, error) {
+		calls.Add(1)
+		if e == 0 {
+			close(failed)
+			return false, errTest
+		}
+		if e >= 50 {
+			// the second worker goes on only after the first one failed
+			<-failed
+			time.Sleep(100 * time.Microsecond)
+		}
+		return true, nil
+	})
+	if !errors.Is(err, errTest) {
+		t.Fatalf("ParallelFilter = %v, want errTest rather than the cancellation of the other workers", err)
+	}
+	if n := calls.Load(); n >= 51 {
+		t.Fatalf("f called %d times, the error did not stop the other worker", n)
+	}
+}
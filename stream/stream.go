@@ -0,0 +1,69 @@
+// Package stream provides lazy combinators over container.Iterator.
+// Intermediate operations return iterators which compute nothing until they are advanced,
+// terminal operations consume the iterator and report its error.
+package stream
+
+import (
+	"errors"
+
+	container "github.com/YuukiKazuto/go-container"
+)
+
+var ErrNoElement = errors.New("error: iterator cannot be empty")
+
+// Pair holds the elements produced by Zip and Enumerate
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// iterator adapts a pull function, which returns false once there is no element left,
+// to container.Iterator
+type iterator[E any] struct {
+	pull    func() (E, bool)
+	err     func() error
+	e       E
+	ok      bool
+	fetched bool
+}
+
+func newIterator[E any](pull func() (E, bool), err func() error) *iterator[E] {
+	return &iterator[E]{
+		pull: pull,
+		err:  err,
+	}
+}
+
+func (it *iterator[E]) fetch() {
+	if !it.fetched {
+		it.e, it.ok = it.pull()
+		it.fetched = true
+	}
+}
+
+func (it *iterator[E]) HasNext() bool {
+	it.fetch()
+	return it.ok
+}
+
+func (it *iterator[E]) Next() E {
+	it.fetch()
+	e := it.e
+	var zero E
+	it.e = zero
+	it.fetched = false
+	return e
+}
+
+func (it *iterator[E]) Err() error {
+	return it.err()
+}
+
+// next is the pull function of it
+func next[E any](it container.Iterator[E]) (E, bool) {
+	if !it.HasNext() {
+		var e E
+		return e, false
+	}
+	return it.Next(), true
+}
@@ -0,0 +1,264 @@
+package stream
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	container "github.com/YuukiKazuto/go-container"
+)
+
+var errTest = errors.New("error: test iterator cannot be read")
+
+// failingIterator returns es, then stops with err
+type failingIterator[E any] struct {
+	es  []E
+	err error
+}
+
+func (it *failingIterator[E]) HasNext() bool {
+	return len(it.es) > 0
+}
+
+func (it *failingIterator[E]) Next() E {
+	e := it.es[0]
+	it.es = it.es[1:]
+	return e
+}
+
+func (it *failingIterator[E]) Err() error {
+	if len(it.es) > 0 {
+		return nil
+	}
+	return it.err
+}
+
+func of[E any](es ...E) container.Iterator[E] {
+	return container.NewSliceIterator(es)
+}
+
+// collect iterates it to the end
+func collect[E any](t *testing.T, it container.Iterator[E]) []E {
+	t.Helper()
+	es := []E{}
+	for it.HasNext() {
+		es = append(es, it.Next())
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return es
+}
+
+func TestWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		es   []int
+		size int
+		want [][]int
+	}{
+		{"empty", nil, 2, [][]int{}},
+		{"shorter than size", []int{1}, 2, [][]int{}},
+		{"exactly size", []int{1, 2}, 2, [][]int{{1, 2}}},
+		{"sliding", []int{1, 2, 3, 4}, 3, [][]int{{1, 2, 3}, {2, 3, 4}}},
+		{"size 0", []int{1, 2}, 0, [][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(t, Window(of(tt.es...), tt.size)); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Window = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWindowReturnsNewSlices(t *testing.T) {
+	it := Window(of(1, 2, 3), 2)
+	first := it.Next()
+	it.Next()
+	if !reflect.DeepEqual(first, []int{1, 2}) {
+		t.Fatalf("first window = %v after the second one, want [1 2]", first)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		es   []int
+		size int
+		want [][]int
+	}{
+		{"empty", nil, 2, [][]int{}},
+		{"exact", []int{1, 2, 3, 4}, 2, [][]int{{1, 2}, {3, 4}}},
+		{"short final chunk", []int{1, 2, 3, 4, 5}, 2, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"larger than input", []int{1, 2}, 5, [][]int{{1, 2}}},
+		{"size 0", []int{1, 2}, 0, [][]int{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(t, Chunk(of(tt.es...), tt.size)); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Chunk = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	repeat := func(n int) container.Iterator[int] {
+		es := make([]int, n)
+		for i := range es {
+			es[i] = n
+		}
+		return of(es...)
+	}
+	tests := []struct {
+		name string
+		es   []int
+		want []int
+	}{
+		{"empty", nil, []int{}},
+		{"empty inner iterators", []int{0, 0}, []int{}},
+		{"mixed", []int{0, 2, 0, 1, 3}, []int{2, 2, 1, 3, 3, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(t, FlatMap(of(tt.es...), repeat)); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("FlatMap = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZip(t *testing.T) {
+	tests := []struct {
+		name string
+		a    []int
+		b    []string
+		want []Pair[int, string]
+	}{
+		{"empty", nil, nil, []Pair[int, string]{}},
+		{"one empty", []int{1, 2}, nil, []Pair[int, string]{}},
+		{"a shorter", []int{1}, []string{"a", "b"}, []Pair[int, string]{{1, "a"}}},
+		{"b shorter", []int{1, 2, 3}, []string{"a", "b"}, []Pair[int, string]{{1, "a"}, {2, "b"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(t, Zip(of(tt.a...), of(tt.b...))); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Zip = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZipLeavesTheLongerIterator(t *testing.T) {
+	a, b := of(1, 2, 3), of("a")
+	collect(t, Zip(a, b))
+	if got := collect(t, a); !reflect.DeepEqual(got, []int{2, 3}) {
+		t.Fatalf("rest of the longer iterator = %v, want [2 3]", got)
+	}
+}
+
+func TestTakeWhile(t *testing.T) {
+	less := func(n int) func(int) bool {
+		return func(e int) bool {
+			return e < n
+		}
+	}
+	tests := []struct {
+		name string
+		es   []int
+		pred func(int) bool
+		want []int
+	}{
+		{"empty", nil, less(3), []int{}},
+		{"first fails", []int{5, 1}, less(3), []int{}},
+		{"prefix", []int{1, 2, 3, 1}, less(3), []int{1, 2}},
+		{"all", []int{1, 2}, less(3), []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(t, TakeWhile(of(tt.es...), tt.pred)); !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("TakeWhile = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrPropagates(t *testing.T) {
+	failing := func() container.Iterator[int] {
+		return &failingIterator[int]{es: []int{1, 2, 3}, err: errTest}
+	}
+	double := func(e int) int {
+		return 2 * e
+	}
+	tests := []struct {
+		name string
+		it   func() container.Iterator[int]
+	}{
+		{"Map", func() container.Iterator[int] { return Map(failing(), double) }},
+		{"Filter", func() container.Iterator[int] {
+			return Filter(failing(), func(int) bool { return true })
+		}},
+		{"FlatMap outer", func() container.Iterator[int] {
+			return FlatMap(failing(), func(e int) container.Iterator[int] { return of(e) })
+		}},
+		{"FlatMap inner", func() container.Iterator[int] {
+			return FlatMap(of(1, 2), func(int) container.Iterator[int] { return failing() })
+		}},
+		{"Take", func() container.Iterator[int] { return Take(failing(), 10) }},
+		{"Skip", func() container.Iterator[int] { return Skip(failing(), 1) }},
+		{"TakeWhile", func() container.Iterator[int] {
+			return TakeWhile(failing(), func(int) bool { return true })
+		}},
+		{"Chain", func() container.Iterator[int] { return Chain(of(0), failing(), of(4)) }},
+		{"Distinct", func() container.Iterator[int] { return Distinct(failing()) }},
+		{"Window", func() container.Iterator[int] {
+			return Map(Window(failing(), 2), func(w []int) int { return w[0] })
+		}},
+		{"Chunk", func() container.Iterator[int] {
+			return Map(Chunk(failing(), 2), func(c []int) int { return c[0] })
+		}},
+		{"Zip", func() container.Iterator[int] {
+			return Map(Zip(of(1, 2, 3, 4), failing()), func(p Pair[int, int]) int { return p.Second })
+		}},
+		{"Enumerate", func() container.Iterator[int] {
+			return Map(Enumerate(failing()), func(p Pair[int, int]) int { return p.Second })
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Count(tt.it()); !errors.Is(err, errTest) {
+				t.Errorf("Count = %v, want errTest", err)
+			}
+			if _, err := CollectToSliceList(tt.it()); !errors.Is(err, errTest) {
+				t.Errorf("CollectToSliceList = %v, want errTest", err)
+			}
+			if _, err := Reduce(tt.it(), 0, func(r, e int) int { return r + e }); !errors.Is(err, errTest) {
+				t.Errorf("Reduce = %v, want errTest", err)
+			}
+		})
+	}
+}
+
+func TestFlatMapStopsAtInnerError(t *testing.T) {
+	calls := 0
+	it := FlatMap(of(1, 2, 3), func(e int) container.Iterator[int] {
+		calls++
+		return &failingIterator[int]{es: []int{e}, err: errTest}
+	})
+	if got, err := Count(it); got != 1 || !errors.Is(err, errTest) {
+		t.Fatalf("Count = %v, %v, want 1, errTest", got, err)
+	}
+	if calls != 1 {
+		t.Fatalf("f called %d times after an inner error, want 1", calls)
+	}
+}
+
+func TestFirst(t *testing.T) {
+	if _, err := First(of[int]()); !errors.Is(err, ErrNoElement) {
+		t.Errorf("First of an empty iterator = %v, want ErrNoElement", err)
+	}
+	if _, err := First[int](&failingIterator[int]{err: errTest}); !errors.Is(err, errTest) {
+		t.Errorf("First of a failing iterator = %v, want errTest", err)
+	}
+}
@@ -0,0 +1,72 @@
+package stream
+
+import container "github.com/YuukiKazuto/go-container"
+
+// Reduce folds the elements of it into init with f
+func Reduce[E, R any](it container.Iterator[E], init R, f func(R, E) R) (R, error) {
+	r := init
+	for it.HasNext() {
+		r = f(r, it.Next())
+	}
+	return r, it.Err()
+}
+
+// Count returns the number of elements of it
+func Count[E any](it container.Iterator[E]) (int, error) {
+	n := 0
+	for it.HasNext() {
+		it.Next()
+		n++
+	}
+	return n, it.Err()
+}
+
+// Any reports whether pred is true for any element of it, it stops at the first one
+func Any[E any](it container.Iterator[E], pred func(E) bool) (bool, error) {
+	for it.HasNext() {
+		if pred(it.Next()) {
+			return true, nil
+		}
+	}
+	return false, it.Err()
+}
+
+// All reports whether pred is true for all the elements of it, it stops at the first one which is not
+func All[E any](it container.Iterator[E], pred func(E) bool) (bool, error) {
+	for it.HasNext() {
+		if !pred(it.Next()) {
+			return false, nil
+		}
+	}
+	return true, it.Err()
+}
+
+// First returns the first element of it, or ErrNoElement if there is none
+func First[E any](it container.Iterator[E]) (E, error) {
+	if it.HasNext() {
+		return it.Next(), nil
+	}
+	var e E
+	if err := it.Err(); err != nil {
+		return e, err
+	}
+	return e, ErrNoElement
+}
+
+// CollectToSliceList returns a SliceList holding the elements of it
+func CollectToSliceList[E comparable](it container.Iterator[E]) (*container.SliceList[E], error) {
+	l := container.NewSliceList[E]()
+	for it.HasNext() {
+		l.Add(it.Next())
+	}
+	return l, it.Err()
+}
+
+// CollectToLinkedList returns a LinkedList holding the elements of it
+func CollectToLinkedList[E comparable](it container.Iterator[E]) (*container.LinkedList[E], error) {
+	l := container.NewLinkedList[E]()
+	for it.HasNext() {
+		l.Add(it.Next())
+	}
+	return l, it.Err()
+}
@@ -0,0 +1,642 @@
+package container
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// subListParent is implemented by the lists which views can be created on,
+// the unexported methods must be called with the lock returned by rwMutex held
+type subListParent[E any] interface {
+	List[E]
+	rwMutex() *sync.RWMutex
+	getModCount() int
+	eq(a, b E) bool
+	elemEquality() equality[E]
+	size() int
+	getAt(i int) E
+	setAt(i int, e E) error
+	insertAt(i int, e E) error
+	insertAll(i int, es []E) error
+	removeAt(i int) E
+	removeRange(from, to int)
+	replaceRange(from, to int, es []E) error
+	removeIf(from, to int, pred func(E) bool) int
+	// forRange calls fn with the elements in [from, to) in one pass, until fn returns false
+	forRange(from, to int, fn func(i int, e E) bool)
+	// setRange overwrites the elements from index from with es
+	setRange(from int, es []E) error
+}
+
+// subList is a view of the elements in [offset, offset+len) of its parent.
+// modCount is the modification count of the parent which the view expects,
+// it is refreshed whenever the parent is modified through the view.
+// err is the first error met by the methods which cannot return one.
+type subList[E any] struct {
+	UnimplementedSqContainer[E]
+	parent   subListParent[E]
+	offset   int
+	len      int
+	modCount int
+	err      error
+	errMu    sync.Mutex
+}
+
+// newSubList must be called with the lock of parent held
+func newSubList[E any](parent subListParent[E], from, to int) (View[E], error) {
+	if err := checkRange(from, to, parent.size()); err != nil {
+		return nil, err
+	}
+	return &subList[E]{
+		parent:   parent,
+		offset:   from,
+		len:      to - from,
+		modCount: parent.getModCount(),
+	}, nil
+}
+
+func (sl *subList[E]) check() error {
+	if sl.parent.getModCount() != sl.modCount {
+		return ErrConcurrentModification
+	}
+	return nil
+}
+
+// fail records err for Err
+func (sl *subList[E]) fail(err error) {
+	sl.errMu.Lock()
+	defer sl.errMu.Unlock()
+	if sl.err == nil {
+		sl.err = err
+	}
+}
+
+// stale is check for the methods which cannot return an error, it records the error
+func (sl *subList[E]) stale() bool {
+	if err := sl.check(); err != nil {
+		sl.fail(err)
+		return true
+	}
+	return false
+}
+
+// staleIterator returns an iterator which only reports err
+func (sl *subList[E]) staleIterator(err error) ListIterator[E] {
+	return &sqListIterator[E]{l: sl, lastRet: -1, err: err}
+}
+
+func (sl *subList[E]) sync(delta int) {
+	sl.len += delta
+	sl.modCount = sl.parent.getModCount()
+}
+
+// resync is sync for a modification of the root list made by an iterator of the view,
+// which the views between the root and the view have not seen
+func (sl *subList[E]) resync(delta int) {
+	if parent, ok := sl.parent.(*subList[E]); ok {
+		parent.resync(delta)
+	}
+	sl.sync(delta)
+}
+
+// root returns the list at the top of the chain of views and the offset of the view in it
+func (sl *subList[E]) root() (subListParent[E], int) {
+	offset := sl.offset
+	parent := sl.parent
+	for {
+		view, ok := parent.(*subList[E])
+		if !ok {
+			return parent, offset
+		}
+		offset += view.offset
+		parent = view.parent
+	}
+}
+
+func (sl *subList[E]) elems() []E {
+	es := make([]E, 0, sl.len)
+	sl.forRange(0, sl.len, func(_ int, e E) bool {
+		es = append(es, e)
+		return true
+	})
+	return es
+}
+
+func (sl *subList[E]) rwMutex() *sync.RWMutex {
+	return sl.parent.rwMutex()
+}
+
+func (sl *subList[E]) eq(a, b E) bool {
+	return sl.parent.eq(a, b)
+}
+
+func (sl *subList[E]) elemEquality() equality[E] {
+	return sl.parent.elemEquality()
+}
+
+func (sl *subList[E]) getModCount() int {
+	return sl.parent.getModCount()
+}
+
+func (sl *subList[E]) size() int {
+	return sl.len
+}
+
+func (sl *subList[E]) getAt(i int) E {
+	return sl.parent.getAt(sl.offset + i)
+}
+
+func (sl *subList[E]) setAt(i int, e E) error {
+	return sl.parent.setAt(sl.offset+i, e)
+}
+
+func (sl *subList[E]) insertAt(i int, e E) error {
+	if err := sl.parent.insertAt(sl.offset+i, e); err != nil {
+		return err
+	}
+	sl.sync(1)
+	return nil
+}
+
+func (sl *subList[E]) removeAt(i int) E {
+	e := sl.parent.removeAt(sl.offset + i)
+	sl.sync(-1)
+	return e
+}
+
+func (sl *subList[E]) removeRange(from, to int) {
+	sl.parent.removeRange(sl.offset+from, sl.offset+to)
+	sl.sync(from - to)
+}
+
+func (sl *subList[E]) replaceRange(from, to int, es []E) error {
+	if err := sl.parent.replaceRange(sl.offset+from, sl.offset+to, es); err != nil {
+		return err
+	}
+	sl.sync(len(es) - (to - from))
+	return nil
+}
+
+func (sl *subList[E]) removeIf(from, to int, pred func(E) bool) int {
+	n := sl.parent.removeIf(sl.offset+from, sl.offset+to, pred)
+	sl.sync(-n)
+	return n
+}
+
+func (sl *subList[E]) forRange(from, to int, fn func(i int, e E) bool) {
+	sl.parent.forRange(sl.offset+from, sl.offset+to, func(i int, e E) bool {
+		return fn(i-sl.offset, e)
+	})
+}
+
+func (sl *subList[E]) setRange(from int, es []E) error {
+	return sl.parent.setRange(sl.offset+from, es)
+}
+
+func (sl *subList[E]) insertAll(i int, es []E) error {
+	if err := sl.parent.insertAll(sl.offset+i, es); err != nil {
+		return err
+	}
+	sl.sync(len(es))
+	return nil
+}
+
+func (sl *subList[E]) Clear() {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return
+	}
+	sl.removeRange(0, sl.len)
+}
+
+func (sl *subList[E]) Get(i int) (E, error) {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	var e E
+	if err := sl.check(); err != nil {
+		return e, err
+	}
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= sl.len {
+		return e, ErrIndexGteSize
+	}
+	return sl.getAt(i), nil
+}
+
+func (sl *subList[E]) IsEmpty() bool {
+	return sl.Size() == 0
+}
+
+// Err returns the first error met by the methods of the view which cannot return one
+func (sl *subList[E]) Err() error {
+	sl.errMu.Lock()
+	defer sl.errMu.Unlock()
+	return sl.err
+}
+
+func (sl *subList[E]) Iterator() Iterator[E] {
+	return sl.ListIterator()
+}
+
+func (sl *subList[E]) ModCount() int {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	return sl.parent.getModCount()
+}
+
+func (sl *subList[E]) Size() int {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	if sl.stale() {
+		return 0
+	}
+	return sl.len
+}
+
+func (sl *subList[E]) SnapshotIterator() Iterator[E] {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	if err := sl.check(); err != nil {
+		sl.fail(err)
+		return sl.staleIterator(err)
+	}
+	root, offset := sl.root()
+	if ll, ok := root.(*LinkedList[E]); ok {
+		return newLinkedSnapshotIterator[E](&ll.rw, &ll.modCount, &ll.snaps, ll.getNode(offset), sl.len)
+	}
+	return NewSliceIterator[E](sl.elems())
+}
+
+func (sl *subList[E]) reverseSnapshotIterator() (Iterator[E], int) {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	if err := sl.check(); err != nil {
+		sl.fail(err)
+		return sl.staleIterator(err), 0
+	}
+	root, offset := sl.root()
+	if ll, ok := root.(*LinkedList[E]); ok {
+		last := ll.getNode(offset + sl.len - 1)
+		return newReverseLinkedSnapshotIterator[E](&ll.rw, &ll.modCount, &ll.snaps, last, sl.len), sl.len
+	}
+	return NewSliceIterator[E](reverse(sl.elems())), sl.len
+}
+
+func (sl *subList[E]) ToSlice() []E {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	if sl.stale() {
+		return nil
+	}
+	return sl.elems()
+}
+
+// Add inserts e at the end of the view,
+// Err reports ErrSortedPosition if the parent cannot place elements by index
+func (sl *subList[E]) Add(e E) {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return
+	}
+	if err := sl.insertAt(sl.len, e); err != nil {
+		sl.fail(err)
+	}
+}
+
+func (sl *subList[E]) AddAll(es ...E) {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return
+	}
+	if err := sl.insertAll(sl.len, es); err != nil {
+		sl.fail(err)
+	}
+}
+
+func (sl *subList[E]) AddToIndex(i int, e E) error {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	if i < 0 {
+		return ErrIndexLtZero
+	}
+	if i > sl.len {
+		return ErrIndexGtSize
+	}
+	return sl.insertAt(i, e)
+}
+
+func (sl *subList[E]) AddList(l List[E]) error {
+	if l == sl {
+		return ErrSelf
+	}
+	es := l.ToSlice()
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	return sl.insertAll(sl.len, es)
+}
+
+func (sl *subList[E]) AddListToIndex(i int, l List[E]) error {
+	if l == sl {
+		return ErrSelf
+	}
+	es := l.ToSlice()
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	if i < 0 {
+		return ErrIndexLtZero
+	}
+	if i > sl.len {
+		return ErrIndexGtSize
+	}
+	return sl.insertAll(i, es)
+}
+
+func (sl *subList[E]) Contains(e E) bool {
+	return sl.IndexOf(e) != NotFound
+}
+
+func (sl *subList[E]) ContainsAll(c Container[E]) bool {
+	es := c.ToSlice()
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	if sl.stale() {
+		return false
+	}
+	return containsAll(sl.elems(), es, sl.parent.elemEquality())
+}
+
+// Copy returns a SliceList holding the elements of the view, which compares them as the parent
+func (sl *subList[E]) Copy() List[E] {
+	return &SliceList[E]{
+		elems:    sl.ToSlice(),
+		equality: sl.parent.elemEquality(),
+	}
+}
+
+func (sl *subList[E]) IndexOf(e E) int {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	index := NotFound
+	if sl.stale() {
+		return index
+	}
+	sl.forRange(0, sl.len, func(i int, x E) bool {
+		if sl.eq(x, e) {
+			index = i
+			return false
+		}
+		return true
+	})
+	return index
+}
+
+func (sl *subList[E]) LastIndexOf(e E) int {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	index := NotFound
+	if sl.stale() {
+		return index
+	}
+	sl.forRange(0, sl.len, func(i int, x E) bool {
+		if sl.eq(x, e) {
+			index = i
+		}
+		return true
+	})
+	return index
+}
+
+func (sl *subList[E]) InsertSlice(i int, es []E) error {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	if i < 0 {
+		return ErrIndexLtZero
+	}
+	if i > sl.len {
+		return ErrIndexGtSize
+	}
+	return sl.insertAll(i, es)
+}
+
+// ListIterator walks the nodes of a LinkedList parent instead of looking each index up
+func (sl *subList[E]) ListIterator() ListIterator[E] {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	if err := sl.check(); err != nil {
+		sl.fail(err)
+		return sl.staleIterator(err)
+	}
+	root, offset := sl.root()
+	if ll, ok := root.(*LinkedList[E]); ok {
+		return newLinkedListViewIterator[E](ll, sl, offset, 0)
+	}
+	return newSqListIterator[E](sl)
+}
+
+func (sl *subList[E]) RemoveElements(e E) bool {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return false
+	}
+	return sl.removeIf(0, sl.len, func(x E) bool {
+		return sl.eq(x, e)
+	}) > 0
+}
+
+func (sl *subList[E]) RemoveStart() (E, error) {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	var e E
+	if err := sl.check(); err != nil {
+		return e, err
+	}
+	if sl.len == 0 {
+		return e, ErrListEmpty
+	}
+	return sl.removeAt(0), nil
+}
+
+func (sl *subList[E]) RemoveLast() (E, error) {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	var e E
+	if err := sl.check(); err != nil {
+		return e, err
+	}
+	if sl.len == 0 {
+		return e, ErrListEmpty
+	}
+	return sl.removeAt(sl.len - 1), nil
+}
+
+func (sl *subList[E]) RemoveByIndex(i int) (E, error) {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	var e E
+	if err := sl.check(); err != nil {
+		return e, err
+	}
+	if i < 0 {
+		return e, ErrIndexLtZero
+	}
+	if i >= sl.len {
+		return e, ErrIndexGteSize
+	}
+	return sl.removeAt(i), nil
+}
+
+func (sl *subList[E]) RemoveAll(c Container[E]) bool {
+	es := c.ToSlice()
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return false
+	}
+	return sl.removeIf(0, sl.len, sl.parent.elemEquality().matcher(es)) > 0
+}
+
+func (sl *subList[E]) RemoveRange(from, to int) error {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	if err := checkRange(from, to, sl.len); err != nil {
+		return err
+	}
+	sl.removeRange(from, to)
+	return nil
+}
+
+func (sl *subList[E]) ReplaceRange(from, to int, es []E) error {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	if err := checkRange(from, to, sl.len); err != nil {
+		return err
+	}
+	return sl.replaceRange(from, to, es)
+}
+
+func (sl *subList[E]) RemoveIf(pred func(E) bool) bool {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return false
+	}
+	return sl.removeIf(0, sl.len, pred) > 0
+}
+
+func (sl *subList[E]) RetainAll(c Container[E]) bool {
+	es := c.ToSlice()
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return false
+	}
+	contains := sl.parent.elemEquality().matcher(es)
+	return sl.removeIf(0, sl.len, func(e E) bool {
+		return !contains(e)
+	}) > 0
+}
+
+func (sl *subList[E]) Set(i int, e E) error {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if err := sl.check(); err != nil {
+		return err
+	}
+	if i < 0 {
+		return ErrIndexLtZero
+	}
+	if i >= sl.len {
+		return ErrIndexGteSize
+	}
+	return sl.setAt(i, e)
+}
+
+func (sl *subList[E]) Sort(less func(a, b E) bool) {
+	sl.sort(sortSlice[E]{less: less}, sort.Sort)
+}
+
+func (sl *subList[E]) SortStable(less func(a, b E) bool) {
+	sl.sort(sortSlice[E]{less: less}, sort.Stable)
+}
+
+// sort sorts a copy of the elements and writes them back to the parent,
+// Err reports ErrSortedPosition if the parent cannot place elements by index
+func (sl *subList[E]) sort(s sortSlice[E], sortFunc func(sort.Interface)) {
+	rw := sl.parent.rwMutex()
+	rw.Lock()
+	defer rw.Unlock()
+	if sl.stale() {
+		return
+	}
+	s.es = sl.elems()
+	sortFunc(s)
+	if err := sl.setRange(0, s.es); err != nil {
+		sl.fail(err)
+	}
+}
+
+func (sl *subList[E]) SubList(from, to int) (View[E], error) {
+	rw := sl.parent.rwMutex()
+	rw.RLock()
+	defer rw.RUnlock()
+	if err := sl.check(); err != nil {
+		return nil, err
+	}
+	return newSubList[E](sl, from, to)
+}
+
+func (sl *subList[E]) String() string {
+	return fmt.Sprint(sl.ToSlice())
+}